@@ -0,0 +1,80 @@
+package parsercombinator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Ambiguity is recorded by Or when ParseContext.ReportAmbiguity is enabled and several
+// alternatives match the same (longest) span. Or keeps the first of them.
+type Ambiguity[T any] struct {
+	Pos          *Pos
+	Consumed     int          // Number of tokens every tied alternative consumed
+	Alternatives []int        // Indices (0-based) of the tied alternatives in the Or call
+	Labels       []string     // Trace name of each tied alternative ("" for untraced parsers)
+	Results      [][]Token[T] // Result tokens of each tied alternative
+}
+
+func (a Ambiguity[T]) String() string {
+	options := make([]string, len(a.Alternatives))
+	for i, index := range a.Alternatives {
+		if a.Labels[i] != "" {
+			options[i] = fmt.Sprintf("%d (%s)", index+1, a.Labels[i])
+		} else {
+			options[i] = fmt.Sprintf("%d", index+1)
+		}
+	}
+	return fmt.Sprintf("ambiguous Or options %s consume %d tokens at %s", joinLabels(options, "and"), a.Consumed, a.Pos)
+}
+
+// DumpAmbiguities returns the reported ambiguities, one per line.
+func (pc *ParseContext[T]) DumpAmbiguities() string {
+	builder := strings.Builder{}
+	for _, a := range pc.Ambiguities {
+		builder.WriteString(a.String())
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+type alternativeMatch[T any] struct {
+	index    int
+	label    string
+	consumed int
+	tokens   []Token[T]
+}
+
+// parseAlternative runs an Or alternative and returns the name of the first Trace it entered
+// when ambiguity reporting is enabled.
+func parseAlternative[T any](pctx *ParseContext[T], src []Token[T], parser Parser[T]) (int, []Token[T], string, error) {
+	if !pctx.ReportAmbiguity {
+		consumed, newTokens, err := parser(pctx, src)
+		return consumed, newTokens, "", err
+	}
+	outerDepth, outerLabel := pctx.alternativeDepth, pctx.alternativeLabel
+	pctx.alternativeDepth, pctx.alternativeLabel = pctx.Depth, ""
+	consumed, newTokens, err := parser(pctx, src)
+	label := pctx.alternativeLabel
+	pctx.alternativeDepth, pctx.alternativeLabel = outerDepth, outerLabel
+	return consumed, newTokens, label, err
+}
+
+// reportAmbiguity records the matches that tie with the longest one.
+func reportAmbiguity[T any](pctx *ParseContext[T], src []Token[T], matches []alternativeMatch[T], consumed int) {
+	var tied []alternativeMatch[T]
+	for _, m := range matches {
+		if m.consumed == consumed {
+			tied = append(tied, m)
+		}
+	}
+	if len(tied) < 2 {
+		return
+	}
+	ambiguity := &Ambiguity[T]{Pos: getFirstPos(src), Consumed: consumed}
+	for _, m := range tied {
+		ambiguity.Alternatives = append(ambiguity.Alternatives, m.index)
+		ambiguity.Labels = append(ambiguity.Labels, m.label)
+		ambiguity.Results = append(ambiguity.Results, m.tokens)
+	}
+	pctx.Ambiguities = append(pctx.Ambiguities, ambiguity)
+}
@@ -0,0 +1,60 @@
+package parsercombinator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestAmbiguity(t *testing.T) {
+	// "a b" is both a call and a declaration
+	call := SeqWithLabel("call", rawLiteral("a"), AnyToken[string]())
+	declaration := SeqWithLabel("declaration", AnyToken[string](), rawLiteral("b"))
+	single := rawLiteral("a")
+	parser := Or(single, call, declaration)
+
+	pc := NewParseContext[string]()
+	_, err := EvaluateWithRawTokens(pc, strings.Fields("a b"), parser)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(pc.Ambiguities), "reporting is opt-in")
+
+	pc.ReportAmbiguity = true
+	_, err = EvaluateWithRawTokens(pc, strings.Fields("a b"), parser)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pc.Ambiguities))
+	ambiguity := pc.Ambiguities[0]
+	assert.Equal(t, []int{1, 2}, ambiguity.Alternatives)
+	assert.Equal(t, []string{"call", "declaration"}, ambiguity.Labels)
+	assert.Equal(t, 2, ambiguity.Consumed)
+	assert.Equal(t, 2, len(ambiguity.Results))
+	assert.Equal(t, "ambiguous Or options 2 (call) and 3 (declaration) consume 2 tokens at 0\n", pc.DumpAmbiguities())
+
+	_, err = EvaluateWithRawTokens(pc, strings.Fields("a c"), parser)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(pc.Ambiguities))
+
+	_, err = EvaluateWithRawTokens(pc, strings.Fields("a"), Or(single, rawLiteral("a")))
+	assert.NoError(t, err)
+	assert.Equal(t, "ambiguous Or options 1 and 2 consume 1 tokens at 0\n", pc.DumpAmbiguities())
+}
+
+func TestAmbiguityInAbandonedBranch(t *testing.T) {
+	pc := NewParseContext[string]()
+	pc.ReportAmbiguity = true
+
+	inner := Or(SeqWithLabel("x1", rawLiteral("x")), SeqWithLabel("x2", rawLiteral("x")))
+	parser := Or(
+		SeqWithLabel("short", inner, rawLiteral("y")),
+		SeqWithLabel("long", Or(SeqWithLabel("x3", rawLiteral("x")), SeqWithLabel("xy", rawLiteral("x"), rawLiteral("y"))), rawLiteral("z")),
+	)
+	_, err := EvaluateWithRawTokens(pc, strings.Fields("x y z"), parser)
+	assert.NoError(t, err)
+	// the ambiguity inside the shorter alternative is discarded
+	assert.Equal(t, 0, len(pc.Ambiguities))
+
+	_, err = EvaluateWithRawTokens(pc, strings.Fields("x y"), parser)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pc.Ambiguities))
+	assert.Equal(t, []string{"x1", "x2"}, pc.Ambiguities[0].Labels)
+}
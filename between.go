@@ -0,0 +1,89 @@
+package parsercombinator
+
+import (
+	"errors"
+	"fmt"
+)
+
+type openDelimiter[T any] struct {
+	token Token[T]
+	close Parser[T]
+}
+
+// Between matches open, body and close in sequence and returns only the body's tokens.
+// open must consume at least one token; it is the opening delimiter the errors refer to.
+//
+// When close doesn't match, the error refers to the opening delimiter:
+//   - at the end of input: "unclosed '(' opened at 3:5" (located at the opening delimiter)
+//   - at a token that closes an enclosing Between, like ']' in "[ ( ]":
+//     "mismatched ']' (expected to close '(' opened at 1:3)"
+//   - otherwise the close parser's error is annotated with "(to close '(' opened at 3:5)"
+func Between[T any](open, close, body Parser[T]) Parser[T] {
+	return trace("between", func(pctx *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		offset, _, err := open(pctx, src)
+		if err != nil {
+			return 0, nil, err
+		}
+		if offset == 0 {
+			// the opening delimiter is needed to report unclosed and mismatched delimiters
+			return 0, nil, NewErrCritical("Between open parser must consume a token", getFirstPos(src))
+		}
+		opened := openDelimiter[T]{token: src[0], close: close}
+		enclosing := pctx.delimiters
+		pctx.delimiters = append(enclosing, opened)
+		defer func() {
+			pctx.delimiters = enclosing
+		}()
+
+		consumed, newTokens, err := body(pctx, src[offset:])
+		if err != nil {
+			return 0, nil, err
+		}
+		offset += consumed
+
+		rest := src[offset:]
+		if len(rest) == 0 {
+			return 0, nil, newErrNotMatchMessage(fmt.Sprintf("unclosed %s opened", describeToken(opened.token)),
+				expectedAt(pctx, close, rest), "EOF", opened.token.Pos)
+		}
+		consumed, _, err = close(pctx, rest)
+		if err == nil {
+			return offset + consumed, newTokens, nil
+		}
+		if !errors.Is(err, ErrNotMatch) && !errors.Is(err, ErrRepeatCount) {
+			return 0, nil, err
+		}
+		for i := len(enclosing) - 1; i >= 0; i-- {
+			if _, _, closeErr := enclosing[i].close(pctx, rest); closeErr == nil {
+				var expected []string
+				var nm *NotMatchError
+				if errors.As(err, &nm) {
+					expected = nm.Expected
+				}
+				mismatched := newErrNotMatchMessage(fmt.Sprintf("mismatched %s (expected to close %s opened at %s)",
+					describeToken(rest[0]), describeToken(opened.token), opened.token.Pos),
+					expected, describeToken(rest[0]), rest[0].Pos)
+				mismatched.Labels = openedLabel(opened)
+				return 0, nil, mismatched
+			}
+		}
+		parent, pos := err, rest[0].Pos
+		var pe *ParseError
+		if errors.As(err, &pe) {
+			parent = pe.Parent
+			if pe.Pos != nil {
+				pos = pe.Pos
+			}
+		}
+		return 0, nil, &ParseError{
+			Parent: fmt.Errorf("%w (to close %s opened at %s)", parent, describeToken(opened.token), opened.token.Pos),
+			Pos:    pos,
+			Labels: openedLabel(opened),
+		}
+	})
+}
+
+// openedLabel points FormatError at the opening delimiter.
+func openedLabel[T any](opened openDelimiter[T]) []ErrorLabel {
+	return []ErrorLabel{{Pos: opened.token.Pos, Message: describeToken(opened.token) + " opened here"}}
+}
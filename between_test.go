@@ -0,0 +1,87 @@
+package parsercombinator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestBetween(t *testing.T) {
+	lexer, err := NewLexer[int](
+		SkipRule(`\s+`),
+		RegexpRule("ident", `[a-z]+`),
+		RegexpRule("punct", `[()\[\],]`),
+	)
+	assert.NoError(t, err)
+
+	parens := func(body Parser[int]) Parser[int] {
+		return Between(Literal[int]("("), Literal[int](")"), body)
+	}
+	brackets := func(body Parser[int]) Parser[int] {
+		return Between(Literal[int]("["), Literal[int]("]"), body)
+	}
+	ident := TokenType[int]("ident")
+	args := SepBy("args", ident, Literal[int](","), TrailingForbid)
+
+	tests := []struct {
+		name    string
+		parser  Parser[int]
+		src     string
+		want    []string
+		wantErr string
+
+		wantExpected []string
+		wantActual   string
+	}{
+		{name: "match", parser: parens(args), src: "(a, b)", want: []string{"a", "b"}},
+		{name: "empty body", parser: parens(args), src: "()", want: []string{}},
+		{name: "nested", parser: brackets(parens(args)), src: "[(a)]", want: []string{"a"}},
+		{name: "open not match", parser: parens(args), src: "[a]", wantErr: "not match expected: '(', actual: '[' at 1:1", wantExpected: []string{"'('"}, wantActual: "'['"},
+		{name: "unclosed at EOF", parser: parens(args), src: "\n    (a, b", wantErr: "not match: unclosed '(' opened at 2:5", wantExpected: []string{"')'"}, wantActual: "EOF"},
+		{name: "nested mismatch", parser: brackets(parens(args)), src: "[ (a ]", wantErr: "not match: mismatched ']' (expected to close '(' opened at 1:3) at 1:6", wantExpected: []string{"')'"}, wantActual: "']'"},
+		{name: "unexpected token", parser: parens(args), src: "(a b)", wantErr: "not match expected: ')', actual: 'b' (to close '(' opened at 1:1) at 1:4", wantExpected: []string{"')'"}, wantActual: "'b'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := lexer.Tokenize(tt.src)
+			assert.NoError(t, err)
+			pc := NewParseContext[int]()
+			_, err = Evaluate(pc, tokens, tt.parser)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.True(t, errors.Is(err, ErrNotMatch))
+				var nm *NotMatchError
+				assert.True(t, errors.As(err, &nm))
+				assert.Equal(t, tt.wantExpected, nm.Expected)
+				assert.Equal(t, tt.wantActual, nm.Actual)
+				return
+			}
+			assert.NoError(t, err)
+			got := []string{}
+			for _, token := range pc.Results {
+				got = append(got, token.Raw)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Zero(t, pc.delimiters)
+		})
+	}
+}
+
+func TestBetweenPosition(t *testing.T) {
+	pc := NewParseContext[string]()
+	_, err := EvaluateWithRawTokens(pc, []string{"x", "(", "a"}, Seq(rawLiteral("x"), Between(Literal[string]("("), Literal[string](")"), rawLiteral("a"))))
+	var pe *ParseError
+	assert.True(t, errors.As(err, &pe))
+	assert.Equal(t, 1, pe.Pos.Index)
+}
+
+func TestBetweenOpenWithoutToken(t *testing.T) {
+	pc := NewParseContext[string]()
+	parser := Between(Optional(Literal[string]("(")), Literal[string](")"), rawLiteral("a"))
+	for _, src := range [][]string{{}, {"a", ")"}} {
+		_, err := EvaluateWithRawTokens(pc, src, parser)
+		assert.True(t, errors.Is(err, ErrCritical))
+		assert.Contains(t, err.Error(), "Between open parser must consume a token")
+	}
+}
@@ -0,0 +1,28 @@
+package parsercombinator
+
+// Bind runs parser and then the parser that next builds from its result, for grammars whose
+// shape depends on earlier input (a count followed by that many items, a heredoc terminated by
+// its own delimiter):
+//
+//	heredoc := pc.Bind(delimiter, func(result []pc.Token[T]) pc.Parser[T] {
+//		return pc.Seq(pc.ZeroOrMore("body", pc.NoneOf[T](result[0].Raw)), pc.Literal[T](result[0].Raw))
+//	})
+//
+// The tokens of the next parser are returned; keep the tokens of parser in the closure if you need them.
+func Bind[T any](parser Parser[T], next func(result []Token[T]) Parser[T]) Parser[T] {
+	return trace("bind", func(pctx *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		consumed, newTokens, err := parser(pctx, src)
+		if err != nil {
+			return 0, nil, err
+		}
+		following := next(newTokens)
+		if following == nil {
+			return 0, nil, NewErrCritical("Bind callback returned nil parser", getFirstPos(src))
+		}
+		nextConsumed, nextTokens, err := trace("bind-next", following)(pctx, src[consumed:])
+		if err != nil {
+			return 0, nil, err
+		}
+		return consumed + nextConsumed, nextTokens, nil
+	})
+}
@@ -0,0 +1,53 @@
+package parsercombinator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestBind(t *testing.T) {
+	count := rawDigit()
+	item := Trans(AnyToken[int](), func(pctx *ParseContext[int], src []Token[int]) ([]Token[int], error) {
+		return []Token[int]{{Type: "item", Pos: src[0].Pos, Val: len(src[0].Raw)}}, nil
+	})
+	lengthPrefixed := Bind(count, func(result []Token[int]) Parser[int] {
+		return Repeat("items", uint(result[0].Val), result[0].Val, item)
+	})
+	parser := Seq(OneOrMore("records", lengthPrefixed), EOS[int]())
+
+	pc := NewParseContext[int]()
+	pc.TraceEnable = true
+	result, err := EvaluateWithRawTokens(pc, strings.Fields("2 a bb 0 3 ccc d ee"), parser)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 1, 2}, result)
+	assert.Equal(t, 0, pc.Depth)
+	assert.Contains(t, pc.DumpTraceAsText(), "> bind-next")
+
+	_, err = EvaluateWithRawTokens(pc, strings.Fields("3 a bb"), parser)
+	assert.True(t, errors.Is(err, ErrRepeatCount))
+}
+
+func TestBindHeredoc(t *testing.T) {
+	heredoc := Bind(Seq(Drop(rawLiteral("<<")), AnyToken[string]()), func(result []Token[string]) Parser[string] {
+		delimiter := result[0].Raw
+		return Seq(ZeroOrMore("body", NoneOf[string](delimiter)), Drop(rawLiteral(delimiter)))
+	})
+	pc := NewParseContext[string]()
+	_, err := EvaluateWithRawTokens(pc, strings.Fields("<< EOT hello EOF world EOT"), Seq(heredoc, EOS[string]()))
+	assert.NoError(t, err)
+	raws := make([]string, len(pc.Results))
+	for i, r := range pc.Results {
+		raws[i] = r.Raw
+	}
+	assert.Equal(t, []string{"hello", "EOF", "world"}, raws)
+
+	_, err = EvaluateWithRawTokens(pc, strings.Fields("<< EOT hello EOF"), Seq(heredoc, EOS[string]()))
+	assert.True(t, errors.Is(err, ErrNotMatch))
+
+	nilParser := Bind(AnyToken[string](), func([]Token[string]) Parser[string] { return nil })
+	_, err = EvaluateWithRawTokens(pc, []string{"a"}, nilParser)
+	assert.True(t, errors.Is(err, ErrCritical))
+}
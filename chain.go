@@ -0,0 +1,90 @@
+package parsercombinator
+
+import (
+	"errors"
+)
+
+// Combiner folds two operand results and the operator between them into one token.
+type Combiner[T any] func(pctx *ParseContext[T], left, op, right Token[T]) (Token[T], error)
+
+// ChainL1 matches one or more operands separated by op ("term (op term)*") and folds them
+// left-associatively: a - b - c becomes combine(combine(a, -, b), -, c).
+//
+// operand must return exactly one token. The Pos of every folded token spans all the tokens
+// of the sub-expression it represents.
+func ChainL1[T any](operand, op Parser[T], combine Combiner[T]) Parser[T] {
+	return trace("chainl1", func(pctx *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		offset, operands, ops, spans, err := chainOperands(pctx, src, operand, op)
+		if err != nil {
+			return 0, nil, err
+		}
+		result := operands[0]
+		for i, opToken := range ops {
+			result, err = combine(pctx, result, opToken, operands[i+1])
+			if err != nil {
+				return 0, nil, err
+			}
+			result.Pos = spanPos(src[:spans[i+1][1]])
+		}
+		return offset, []Token[T]{result}, nil
+	})
+}
+
+// ChainR1 works like ChainL1 but folds right-associatively: a ^ b ^ c becomes combine(a, ^, combine(b, ^, c)).
+func ChainR1[T any](operand, op Parser[T], combine Combiner[T]) Parser[T] {
+	return trace("chainr1", func(pctx *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		offset, operands, ops, spans, err := chainOperands(pctx, src, operand, op)
+		if err != nil {
+			return 0, nil, err
+		}
+		result := operands[len(operands)-1]
+		for i := len(ops) - 1; i >= 0; i-- {
+			result, err = combine(pctx, operands[i], ops[i], result)
+			if err != nil {
+				return 0, nil, err
+			}
+			result.Pos = spanPos(src[spans[i][0]:offset])
+		}
+		return offset, []Token[T]{result}, nil
+	})
+}
+
+// chainOperands parses "operand (op operand)*". spans[i] holds the start and end offsets of operands[i].
+func chainOperands[T any](pctx *ParseContext[T], src []Token[T], operand, op Parser[T]) (offset int, operands, ops []Token[T], spans [][2]int, err error) {
+	consumed, newTokens, err := operand(pctx, src)
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	first, err := singleOperand(newTokens, src)
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	offset = consumed
+	operands = []Token[T]{first}
+	spans = [][2]int{{0, offset}}
+	for offset < len(src) {
+		saved := pctx.snapshot()
+		opConsumed, opTokens, err := op(pctx, src[offset:])
+		if errors.Is(err, ErrNotMatch) {
+			pctx.noteFailure(err)
+			pctx.restore(saved)
+			break
+		} else if err != nil {
+			return 0, nil, nil, nil, err
+		}
+		rest := src[offset+opConsumed:]
+		consumed, newTokens, err := operand(pctx, rest)
+		if err != nil {
+			return 0, nil, nil, nil, err
+		}
+		right, err := singleOperand(newTokens, rest)
+		if err != nil {
+			return 0, nil, nil, nil, err
+		}
+		ops = append(ops, operatorToken(src[offset:], opTokens))
+		operands = append(operands, right)
+		spans = append(spans, [2]int{offset + opConsumed, offset + opConsumed + consumed})
+		offset += opConsumed + consumed
+	}
+	return offset, operands, ops, spans, nil
+}
@@ -0,0 +1,83 @@
+package parsercombinator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestChain(t *testing.T) {
+	lexer, err := NewLexer[string](
+		SkipRule(`\s+`),
+		RegexpRule("ident", `[a-z]+`),
+		RegexpRule("op", `[-^]`),
+	)
+	assert.NoError(t, err)
+	operand := Trans(TokenType[string]("ident"), func(pc *ParseContext[string], src []Token[string]) ([]Token[string], error) {
+		return []Token[string]{{Type: "expr", Pos: src[0].Pos, Val: src[0].Raw}}, nil
+	})
+	combine := func(pc *ParseContext[string], left, op, right Token[string]) (Token[string], error) {
+		return Token[string]{Type: "expr", Val: "(" + left.Val + " " + op.Raw + " " + right.Val + ")"}, nil
+	}
+
+	tests := []struct {
+		name     string
+		parser   Parser[string]
+		src      string
+		want     string
+		wantPos  Pos
+		consumed int
+	}{
+		{name: "left single", parser: ChainL1(operand, Literal[string]("-"), combine), src: "a", want: "a", wantPos: Pos{Line: 1, Col: 1, Index: 0, Length: 1}, consumed: 1},
+		{name: "left", parser: ChainL1(operand, Literal[string]("-"), combine), src: "a - bb - c", want: "((a - bb) - c)", wantPos: Pos{Line: 1, Col: 1, Index: 0, Length: 10}, consumed: 5},
+		{name: "left stops at other operator", parser: ChainL1(operand, Literal[string]("-"), combine), src: "a - b ^ c", want: "(a - b)", wantPos: Pos{Line: 1, Col: 1, Index: 0, Length: 5}, consumed: 3},
+		{name: "right", parser: ChainR1(operand, Literal[string]("^"), combine), src: "a ^ b ^ c", want: "(a ^ (b ^ c))", wantPos: Pos{Line: 1, Col: 1, Index: 0, Length: 9}, consumed: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := lexer.Tokenize(tt.src)
+			assert.NoError(t, err)
+			pc := NewParseContext[string]()
+			consumed, result, err := tt.parser(pc, tokens)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.consumed, consumed)
+			assert.Equal(t, 1, len(result))
+			assert.Equal(t, tt.want, result[0].Val)
+			assert.Equal(t, tt.wantPos, *result[0].Pos)
+		})
+	}
+}
+
+func TestChainR1InnerPos(t *testing.T) {
+	lexer, err := NewLexer[[]*Pos](SkipRule(`\s+`), RegexpRule("ident", `[a-z]+`), LiteralRule("op", "^"))
+	assert.NoError(t, err)
+	tokens, err := lexer.Tokenize("a ^ b ^ c")
+	assert.NoError(t, err)
+	// collect the positions of the nested (right) results
+	combine := func(pc *ParseContext[[]*Pos], left, op, right Token[[]*Pos]) (Token[[]*Pos], error) {
+		return Token[[]*Pos]{Val: append(right.Val, right.Pos)}, nil
+	}
+	pc := NewParseContext[[]*Pos]()
+	_, result, err := ChainR1(TokenType[[]*Pos]("ident"), Literal[[]*Pos]("^"), combine)(pc, tokens)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Pos{
+		{Line: 1, Col: 9, Index: 8, Length: 1},
+		{Line: 1, Col: 5, Index: 4, Length: 5},
+	}, result[0].Val)
+}
+
+func TestChainErrors(t *testing.T) {
+	combine := func(pc *ParseContext[string], left, op, right Token[string]) (Token[string], error) {
+		return left, nil
+	}
+	pc := NewParseContext[string]()
+	_, err := EvaluateWithRawTokens(pc, []string{"-", "a"}, ChainL1(rawLiteral("a"), Literal[string]("-"), combine))
+	assert.True(t, errors.Is(err, ErrNotMatch))
+
+	_, err = EvaluateWithRawTokens(pc, []string{"a", "-"}, ChainL1(rawLiteral("a"), Literal[string]("-"), combine))
+	assert.EqualError(t, err, "not match expected: a, actual: EOF")
+
+	_, err = EvaluateWithRawTokens(pc, []string{"a", "-", "b"}, ChainR1(rawLiteral("a"), Literal[string]("-"), combine))
+	assert.EqualError(t, err, "not match expected: a, actual: b at 2")
+}
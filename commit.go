@@ -0,0 +1,28 @@
+package parsercombinator
+
+// Cut marks the point of no return in a sequence. It consumes nothing and always succeeds,
+// but once it is passed, a later failure in the same Seq is reported as ErrCommitted instead of
+// ErrNotMatch, so enclosing Or, Optional and Repeat parsers stop backtracking and report it as is:
+//
+//	function := pc.Seq(pc.Literal[T]("func"), pc.Cut[T](), name, params, body)
+//
+// A Cut affects the innermost Seq only; nested sequences have their own.
+func Cut[T any]() Parser[T] {
+	return trace("cut", func(pctx *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		pctx.cut = true
+		return 0, nil, nil
+	})
+}
+
+// Commit works like parser followed by Cut: after parser succeeds, the enclosing sequence is
+// committed to the current alternative.
+func Commit[T any](parser Parser[T]) Parser[T] {
+	return trace("commit", func(pctx *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		consumed, newTokens, err := parser(pctx, src)
+		if err != nil {
+			return 0, nil, err
+		}
+		pctx.cut = true
+		return consumed, newTokens, nil
+	})
+}
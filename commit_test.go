@@ -0,0 +1,111 @@
+package parsercombinator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestCut(t *testing.T) {
+	function := Seq(rawLiteral("func"), Cut[string](), rawLiteral("name"), rawLiteral("("), rawLiteral(")"))
+	fallback := OneOrMore("words", NoneOf[string](";"))
+	statement := Seq(Or(function, fallback), rawLiteral(";"))
+
+	for _, mode := range []OrMode{OrModeSafe, OrModeFast, OrModeTryFast} {
+		t.Run(mode.String(), func(t *testing.T) {
+			pc := NewParseContext[string]()
+			pc.OrMode = mode
+			_, err := EvaluateWithRawTokens(pc, strings.Fields("func name ( ) ;"), statement)
+			assert.NoError(t, err)
+
+			// without the cut, the fallback would accept this statement
+			_, err = EvaluateWithRawTokens(pc, strings.Fields("func name ( ;"), statement)
+			assert.Error(t, err)
+			assert.True(t, errors.Is(err, ErrCommitted))
+			assert.False(t, errors.Is(err, ErrNotMatch))
+			assert.Equal(t, "committed: not match expected: ), actual: ; at 3", err.Error())
+
+			// failures before the cut still backtrack
+			_, err = EvaluateWithRawTokens(pc, strings.Fields("var name ( ;"), statement)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCutScope(t *testing.T) {
+	pc := NewParseContext[string]()
+
+	// the cut of the inner sequence doesn't commit the outer one
+	inner := Seq(rawLiteral("a"), Cut[string](), rawLiteral("b"))
+	parser := Or(Seq(inner, rawLiteral("c")), Seq(inner, rawLiteral("d")))
+	result, err := EvaluateWithRawTokens(pc, strings.Fields("a b d"), parser)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "", ""}, result)
+
+	// committed errors stop repetitions and optional parsers too
+	repeat := ZeroOrMore("items", Seq(rawLiteral("a"), Cut[string](), rawLiteral("b")))
+	_, err = EvaluateWithRawTokens(pc, strings.Fields("a b a c"), repeat)
+	assert.True(t, errors.Is(err, ErrCommitted))
+	_, err = EvaluateWithRawTokens(pc, strings.Fields("a c"), Optional(inner))
+	assert.True(t, errors.Is(err, ErrCommitted))
+}
+
+func TestCommit(t *testing.T) {
+	pc := NewParseContext[string]()
+	keyword := Commit(Seq(rawLiteral("order"), rawLiteral("by")))
+	parser := Or(
+		Seq(keyword, rawLiteral("name")),
+		Seq(rawLiteral("order"), rawLiteral("by"), rawLiteral("id")),
+	)
+	_, err := EvaluateWithRawTokens(pc, strings.Fields("order by name"), parser)
+	assert.NoError(t, err)
+	_, err = EvaluateWithRawTokens(pc, strings.Fields("order by id"), parser)
+	assert.True(t, errors.Is(err, ErrCommitted))
+	_, err = EvaluateWithRawTokens(pc, strings.Fields("order id"), parser)
+	assert.True(t, errors.Is(err, ErrNotMatch))
+}
+
+func TestCommitInLosingAlternative(t *testing.T) {
+	pc := NewParseContext[string]()
+
+	// the shorter alternative commits, but the longer one wins, so the sequence is not committed
+	inner := Or(Commit(rawLiteral("a")), Seq(rawLiteral("a"), rawLiteral("b")))
+	parser := Or(
+		Seq(inner, rawLiteral("c")),
+		Seq(rawLiteral("a"), rawLiteral("b"), rawLiteral("d")),
+	)
+	_, err := EvaluateWithRawTokens(pc, strings.Fields("a b d"), parser)
+	assert.NoError(t, err)
+
+	// a cut inside a lookahead doesn't commit the sequence either
+	parser = Or(
+		Seq(Lookahead(Commit(rawLiteral("a"))), rawLiteral("a"), rawLiteral("c")),
+		Seq(rawLiteral("a"), rawLiteral("d")),
+	)
+	_, err = EvaluateWithRawTokens(pc, strings.Fields("a d"), parser)
+	assert.NoError(t, err)
+}
+
+func TestCommittedErrorKeepsOriginal(t *testing.T) {
+	pc := NewParseContext[string]()
+	parser := Trace("call", Seq(rawLiteral("f"), Cut[string](), rawLiteral("("), rawLiteral(")")))
+	_, err := EvaluateWithRawTokens(pc, strings.Fields("f ( ;"), Or(parser, rawLiteral("g")))
+	assert.True(t, errors.Is(err, ErrCommitted))
+	assert.False(t, errors.Is(err, ErrNotMatch))
+	assert.EqualError(t, err, "committed: not match expected: ), actual: ; at 2 (while parsing call)")
+
+	var nm *NotMatchError
+	assert.True(t, errors.As(err, &nm))
+	assert.Equal(t, []string{")"}, nm.Expected)
+	assert.Equal(t, ";", nm.Actual)
+
+	var pe *ParseError
+	assert.True(t, errors.As(err, &pe))
+	assert.Equal(t, []string{"call"}, pe.RuleStack)
+	var committed *CommittedError
+	assert.True(t, errors.As(err, &committed))
+	assert.True(t, errors.As(committed.Err, &pe))
+	assert.Equal(t, 2, pe.Pos.Index)
+}
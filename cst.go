@@ -0,0 +1,81 @@
+package parsercombinator
+
+import (
+	"strings"
+)
+
+// Trivia is source text that doesn't take part in parsing, such as whitespace and comments.
+type Trivia struct {
+	Type string
+	Raw  string
+	Pos  *Pos
+}
+
+// CST wraps the tokens consumed by parser into a single concrete syntax tree node.
+//
+// The node has Type label, a Pos spanning the consumed tokens and the consumed tokens as Children.
+// Nodes created by nested CST calls replace the tokens they cover, and tokens that parser dropped
+// (Drop, Trans, etc.) are kept as well, so every consumed token ends up in the tree.
+// Combined with Lexer.TokenizeLossless, SourceText of the root node reproduces the source.
+//
+// Tokens are matched to nested nodes by their Pos pointer, so input tokens need distinct positions.
+func CST[T any](label string, parser Parser[T]) Parser[T] {
+	return Trace(label, func(pc *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		consumed, newTokens, err := parser(pc, src)
+		if err != nil {
+			return 0, nil, err
+		}
+		owners := make(map[*Pos]int)
+		for i, t := range newTokens {
+			if t.Children != nil {
+				registerOwner(owners, t, i)
+			}
+		}
+		children := make([]Token[T], 0, consumed)
+		emitted := make(map[int]bool)
+		for _, t := range src[:consumed] {
+			i, ok := owners[t.Pos]
+			if !ok {
+				children = append(children, t)
+			} else if !emitted[i] {
+				children = append(children, newTokens[i])
+				emitted[i] = true
+			}
+		}
+		return consumed, []Token[T]{{Type: label, Pos: spanPos(src[:consumed]), Children: children}}, nil
+	})
+}
+
+func registerOwner[T any](owners map[*Pos]int, t Token[T], owner int) {
+	if t.Pos != nil {
+		owners[t.Pos] = owner
+	}
+	for _, c := range t.Children {
+		registerOwner(owners, c, owner)
+	}
+}
+
+// SourceText concatenates the raw text and trivia of the tokens, walking into CST nodes.
+func SourceText[T any](tokens ...Token[T]) string {
+	var builder strings.Builder
+	for _, t := range tokens {
+		writeSourceText(&builder, t)
+	}
+	return builder.String()
+}
+
+func writeSourceText[T any](builder *strings.Builder, t Token[T]) {
+	for _, trivia := range t.Leading {
+		builder.WriteString(trivia.Raw)
+	}
+	if t.Children != nil {
+		for _, c := range t.Children {
+			writeSourceText(builder, c)
+		}
+	} else {
+		builder.WriteString(t.Raw)
+	}
+	for _, trivia := range t.Trailing {
+		builder.WriteString(trivia.Raw)
+	}
+}
@@ -0,0 +1,109 @@
+package parsercombinator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func newLosslessLexer(t *testing.T) *Lexer[int] {
+	lexer, err := NewLexer[int](
+		LexRule{Type: "space", Pattern: `[ \t\r\n]+`, Skip: true},
+		LexRule{Type: "comment", Pattern: `//[^\n]*`, Skip: true},
+		LiteralRule("let", "let"),
+		RegexpRule("ident", `[a-z]+`),
+		RegexpRule("number", `[0-9]+`),
+		RegexpRule("punct", `[=+;]`),
+	)
+	assert.NoError(t, err)
+	return lexer
+}
+
+func TestTokenizeLossless(t *testing.T) {
+	lexer := newLosslessLexer(t)
+	src := "  // head\nlet x // tail\n=1 \n"
+	tokens, err := lexer.TokenizeLossless(src)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(tokens))
+
+	triviaText := func(trivia []Trivia) string {
+		var raws []string
+		for _, t := range trivia {
+			raws = append(raws, t.Raw)
+		}
+		return strings.Join(raws, "|")
+	}
+	assert.Equal(t, "  |// head|\n", triviaText(tokens[0].Leading))
+	assert.Equal(t, " ", triviaText(tokens[0].Trailing))
+	assert.Equal(t, " |// tail", triviaText(tokens[1].Trailing))
+	assert.Equal(t, "\n", triviaText(tokens[2].Leading))
+	assert.Equal(t, " \n", triviaText(tokens[3].Trailing))
+	assert.Equal(t, "comment", tokens[1].Trailing[1].Type)
+	assert.Equal(t, src, SourceText(tokens...))
+
+	tokens, err = lexer.TokenizeLossless(" // only trivia\n")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(tokens))
+	assert.Equal(t, "eof", tokens[0].Type)
+	assert.Equal(t, " // only trivia\n", SourceText(tokens...))
+
+	tokens, err = lexer.Tokenize(src)
+	assert.NoError(t, err)
+	assert.Zero(t, tokens[0].Leading)
+}
+
+func TestCST(t *testing.T) {
+	lexer := newLosslessLexer(t)
+	operand := Or(CST("name", TokenType[int]("ident")), CST("literal", TokenType[int]("number")))
+	expr := CST("expr", Seq(operand, ZeroOrMore("terms", Seq(Drop(Literal[int]("+")), operand))))
+	statement := CST("let", Seq(Drop(TokenType[int]("let")), TokenType[int]("ident"), Drop(Literal[int]("=")), expr, Drop(Literal[int](";"))))
+	file := CST("file", Seq(ZeroOrMore("statements", statement), EOS[int]()))
+
+	src := "// program\nlet x = 1 + 2; // first\n  let y=x;\n"
+	tokens, err := lexer.TokenizeLossless(src)
+	assert.NoError(t, err)
+	pc := NewParseContext[int]()
+	_, err = Evaluate(pc, tokens, file)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pc.Results))
+
+	root := pc.Results[0]
+	assert.Equal(t, src, SourceText(root))
+	assert.Equal(t, "file", root.Type)
+	assert.Equal(t, 2, len(root.Children))
+
+	first := root.Children[0]
+	var types []string
+	for _, c := range first.Children {
+		types = append(types, c.Type)
+	}
+	assert.Equal(t, []string{"let", "ident", "punct", "expr", "punct"}, types)
+	assert.Equal(t, "// program\nlet x = 1 + 2; // first", SourceText(first))
+	assert.Equal(t, 3, len(first.Children[3].Children))
+	assert.Equal(t, "literal", first.Children[3].Children[2].Type)
+	assert.Equal(t, Pos{Line: 2, Col: 9, Index: 19, Length: 5}, *first.Children[3].Pos)
+}
+
+func TestCSTTriviaOnly(t *testing.T) {
+	lexer := newLosslessLexer(t)
+	statement := CST("let", Seq(TokenType[int]("let"), TokenType[int]("ident"), Literal[int](";")))
+	file := CST("file", Seq(ZeroOrMore("statements", statement), EOS[int]()))
+
+	src := "// only a comment\n"
+	tokens, err := lexer.TokenizeLossless(src)
+	assert.NoError(t, err)
+	pc := NewParseContext[int]()
+	_, err = Evaluate(pc, tokens, file)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pc.Results))
+	assert.Equal(t, src, SourceText(pc.Results[0]))
+}
+
+func TestEOSKeepsUserEOFTokens(t *testing.T) {
+	// only the token TokenizeLossless creates for trivia is consumed, not sentinels of other lexers
+	pc := NewParseContext[int]()
+	_, err := Evaluate(pc, []Token[int]{{Type: "eof", Pos: &Pos{Line: 1, Col: 1}}}, EOS[int]())
+	assert.True(t, errors.Is(err, ErrNotMatch))
+}
@@ -0,0 +1,131 @@
+package parsercombinator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Severity is the importance of a Diagnostic
+type Severity int
+
+const (
+	// SeverityInfo is a hint that doesn't indicate a problem
+	SeverityInfo Severity = iota
+	// SeverityWarning indicates a likely problem in the grammar
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "unknown"
+	}
+}
+
+// Diagnostic codes reported by the library
+const (
+	// DiagOrFastOrder is reported by Or in OrModeTryFast when first match and longest match differ
+	DiagOrFastOrder = "or-fast-order"
+	// DiagTransformLoop is reported by Trans when CheckTransformSafety finds a possible infinite loop
+	DiagTransformLoop = "transform-loop"
+)
+
+// Diagnostic is a warning about the grammar found while parsing. It doesn't stop the parse.
+type Diagnostic struct {
+	Severity   Severity
+	Code       string // One of the Diag* constants
+	Message    string
+	Pos        *Pos   // Parser position
+	Location   string // file:line of the first caller outside of this package ("" if unknown)
+	Suggestion string
+}
+
+func (d Diagnostic) String() string {
+	builder := strings.Builder{}
+	fmt.Fprintf(&builder, "%s[%s]: %s at %s", d.Severity, d.Code, d.Message, d.Pos)
+	if d.Location != "" {
+		fmt.Fprintf(&builder, " (%s)", d.Location)
+	}
+	if d.Suggestion != "" {
+		fmt.Fprintf(&builder, "\n  suggestion: %s", d.Suggestion)
+	}
+	return builder.String()
+}
+
+// DiagnosticSink receives diagnostics as they are reported (see ParseContext.DiagnosticSink).
+type DiagnosticSink interface {
+	Report(d Diagnostic)
+}
+
+// DiagnosticSinkFunc adapts a function to DiagnosticSink.
+type DiagnosticSinkFunc func(d Diagnostic)
+
+func (f DiagnosticSinkFunc) Report(d Diagnostic) {
+	f(d)
+}
+
+// WriterSink writes each diagnostic to w as text, like:
+//
+//	warning[or-fast-order]: Fast mode chose option 1 ... at 1:5 (grammar.go:42)
+//	  suggestion: move option 2 before option 1 in the Or(...) call
+func WriterSink(w io.Writer) DiagnosticSink {
+	return DiagnosticSinkFunc(func(d Diagnostic) {
+		fmt.Fprintln(w, d.String())
+	})
+}
+
+// SlogSink logs each diagnostic to logger, with the code, position, location and suggestion as attributes.
+func SlogSink(logger *slog.Logger) DiagnosticSink {
+	return DiagnosticSinkFunc(func(d Diagnostic) {
+		level := slog.LevelInfo
+		if d.Severity >= SeverityWarning {
+			level = slog.LevelWarn
+		}
+		logger.LogAttrs(context.Background(), level, d.Message,
+			slog.String("code", d.Code),
+			slog.String("pos", d.Pos.String()),
+			slog.String("location", d.Location),
+			slog.String("suggestion", d.Suggestion),
+		)
+	})
+}
+
+// Report records a diagnostic in Diagnostics and passes it to DiagnosticSink if set.
+func (pc *ParseContext[T]) Report(d Diagnostic) {
+	pc.Diagnostics = append(pc.Diagnostics, d)
+	if pc.DiagnosticSink != nil {
+		pc.DiagnosticSink.Report(d)
+	}
+}
+
+// packageDir is the directory of this package, used to skip its frames in callerLocation.
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// callerLocation returns file:line of the first caller outside of this package
+// (tests of this package count as callers).
+func callerLocation() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if frame.File != "" && !strings.HasPrefix(frame.Function, "runtime.") &&
+			(filepath.Dir(frame.File) != packageDir || strings.HasSuffix(frame.File, "_test.go")) {
+			return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
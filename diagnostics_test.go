@@ -0,0 +1,78 @@
+package parsercombinator
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestDiagnosticsOrTryFast(t *testing.T) {
+	var reported []Diagnostic
+	pc := NewParseContext[string]()
+	pc.OrMode = OrModeTryFast
+	pc.DiagnosticSink = DiagnosticSinkFunc(func(d Diagnostic) {
+		reported = append(reported, d)
+	})
+	parser := Or(rawLiteral("a"), Seq(rawLiteral("a"), rawLiteral("b")))
+
+	_, err := EvaluateWithRawTokens(pc, strings.Fields("a b"), parser)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pc.Diagnostics))
+	assert.Equal(t, pc.Diagnostics, reported)
+
+	d := pc.Diagnostics[0]
+	assert.Equal(t, SeverityWarning, d.Severity)
+	assert.Equal(t, DiagOrFastOrder, d.Code)
+	assert.Equal(t, "Fast mode chose option 1 (consumed 1 tokens), but longest match would choose option 2 (consumed 2 tokens)", d.Message)
+	assert.Equal(t, &Pos{Index: 0}, d.Pos)
+	assert.True(t, strings.HasPrefix(d.Location, "diagnostics_test.go:"), d.Location)
+	assert.Equal(t, "move option 2 before option 1 in the Or(...) call for Fast mode compatibility", d.Suggestion)
+
+	// diagnostics are reset by Evaluate
+	_, err = EvaluateWithRawTokens(pc, strings.Fields("a"), parser)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(pc.Diagnostics))
+}
+
+func TestDiagnosticsTransformSafety(t *testing.T) {
+	pc := NewParseContext[string]()
+	pc.CheckTransformSafety = true
+	identity := Trans(rawLiteral("a"), func(pctx *ParseContext[string], src []Token[string]) ([]Token[string], error) {
+		return src, nil
+	})
+	_, err := EvaluateWithRawTokens(pc, []string{"a"}, identity)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pc.Diagnostics))
+	assert.Equal(t, DiagTransformLoop, pc.Diagnostics[0].Code)
+	assert.Equal(t, "potential infinite loop in transformation - parser produces same result when applied to transformed tokens", pc.Diagnostics[0].Message)
+}
+
+func TestDiagnosticSinks(t *testing.T) {
+	d := Diagnostic{
+		Severity:   SeverityWarning,
+		Code:       DiagOrFastOrder,
+		Message:    "first match differs",
+		Pos:        &Pos{Line: 1, Col: 5},
+		Location:   "grammar.go:42",
+		Suggestion: "reorder",
+	}
+
+	var text bytes.Buffer
+	WriterSink(&text).Report(d)
+	assert.Equal(t, "warning[or-fast-order]: first match differs at 1:5 (grammar.go:42)\n  suggestion: reorder\n", text.String())
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return a
+	}}))
+	SlogSink(logger).Report(d)
+	assert.Equal(t, `level=WARN msg="first match differs" code=or-fast-order pos=1:5 location=grammar.go:42 suggestion=reorder`+"\n", logs.String())
+
+	assert.Equal(t, "info", SeverityInfo.String())
+}
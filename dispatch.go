@@ -0,0 +1,56 @@
+package parsercombinator
+
+import (
+	"slices"
+)
+
+// Dispatch chooses one parser by the key of the next token instead of trying every alternative like Or:
+//
+//	statement := pc.Dispatch("statement", func(t pc.Token[T]) string { return t.Raw }, map[string]pc.Parser[T]{
+//		"if":     ifStatement,
+//		"while":  whileStatement,
+//		"return": returnStatement,
+//	}, expressionStatement)
+//
+// When no case matches the key (or at the end of input), fallback is used. A nil fallback makes
+// Dispatch fail with a not match error listing the keys. The chosen parser's result or error is
+// returned as is; the fallback isn't tried when a case fails.
+func Dispatch[T any](label string, key func(token Token[T]) string, cases map[string]Parser[T], fallback Parser[T]) Parser[T] {
+	return dispatch(label, key, func(key string) string { return key }, cases, fallback)
+}
+
+// SwitchType is Dispatch keyed on the Type of the next token.
+func SwitchType[T any](label string, cases map[string]Parser[T], fallback Parser[T]) Parser[T] {
+	return dispatch(label, func(token Token[T]) string { return token.Type }, func(key string) string { return key }, cases, fallback)
+}
+
+// SwitchRaw is Dispatch keyed on the Raw text of the next token, typically keywords.
+func SwitchRaw[T any](label string, cases map[string]Parser[T], fallback Parser[T]) Parser[T] {
+	return dispatch(label, func(token Token[T]) string { return token.Raw }, quoteRaw, cases, fallback)
+}
+
+func dispatch[T any](label string, key func(token Token[T]) string, describeKey func(key string) string, cases map[string]Parser[T], fallback Parser[T]) Parser[T] {
+	keys := make([]string, 0, len(cases))
+	for k := range cases {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	expected := make([]string, len(keys))
+	for i, k := range keys {
+		expected[i] = describeKey(k)
+	}
+	return Trace(label, func(pctx *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		if len(src) > 0 {
+			if parser, ok := cases[key(src[0])]; ok {
+				return parser(pctx, src)
+			}
+		}
+		if fallback != nil {
+			return fallback(pctx, src)
+		}
+		if len(src) == 0 {
+			return 0, nil, newErrNotMatch(expected, "EOF", nil)
+		}
+		return 0, nil, newErrNotMatch(expected, describeToken(src[0]), src[0].Pos)
+	})
+}
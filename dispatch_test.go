@@ -0,0 +1,76 @@
+package parsercombinator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestSwitchRaw(t *testing.T) {
+	var calls []string
+	statement := func(name string, parser Parser[string]) Parser[string] {
+		return func(pctx *ParseContext[string], src []Token[string]) (int, []Token[string], error) {
+			calls = append(calls, name)
+			return parser(pctx, src)
+		}
+	}
+	cases := map[string]Parser[string]{
+		"print":  statement("print", Seq(rawLiteral("print"), AnyToken[string]())),
+		"return": statement("return", Seq(rawLiteral("return"), AnyToken[string]())),
+	}
+	withFallback := SwitchRaw("statement", cases, statement("expression", AnyToken[string]()))
+	withoutFallback := SwitchRaw("statement", cases, nil)
+
+	tests := []struct {
+		name     string
+		parser   Parser[string]
+		input    string
+		calls    []string
+		consumed int
+		wantErr  string
+	}{
+		{name: "case", parser: withFallback, input: "return x", calls: []string{"return"}, consumed: 2},
+		{name: "fallback", parser: withFallback, input: "x", calls: []string{"expression"}, consumed: 1},
+		{name: "case failure", parser: withFallback, input: "print", calls: []string{"print"}, wantErr: "not match expected: any token, actual: EOF (while parsing statement)"},
+		{name: "no case", parser: withoutFallback, input: "x", wantErr: "not match expected: 'print' or 'return', actual: 'x' at 0 (while parsing statement)"},
+		{name: "EOF", parser: withoutFallback, input: "", wantErr: "not match expected: 'print' or 'return', actual: EOF (while parsing statement)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			pc := NewParseContext[string]()
+			_, err := EvaluateWithRawTokens(pc, strings.Fields(tt.input), tt.parser)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.consumed, pc.Pos)
+			}
+			assert.Equal(t, tt.calls, calls)
+		})
+	}
+}
+
+func TestSwitchType(t *testing.T) {
+	tokens := []Token[string]{
+		{Type: "number", Raw: "1"},
+		{Type: "ident", Raw: "x"},
+		{Type: "string", Raw: `"s"`},
+	}
+	value := SwitchType("value", map[string]Parser[string]{
+		"number": TokenType[string]("number"),
+		"ident":  TokenType[string]("ident"),
+	}, nil)
+	pc := NewParseContext[string]()
+	_, err := Evaluate(pc, tokens, Seq(value, value, value))
+	assert.True(t, errors.Is(err, ErrNotMatch))
+	assert.Equal(t, `not match expected: ident or number, actual: '"s"' (while parsing value)`, err.Error())
+
+	upper := Dispatch("upper", func(token Token[string]) string { return strings.ToUpper(token.Raw) }, map[string]Parser[string]{
+		"SELECT": AnyToken[string](),
+	}, nil)
+	_, err = EvaluateWithRawTokens(pc, []string{"select"}, upper)
+	assert.NoError(t, err)
+}
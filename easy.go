@@ -5,11 +5,15 @@ import (
 )
 
 // EOS: Parser that matches only if there are no remaining input tokens.
+// The "eof" token that Lexer.TokenizeLossless emits for trivia-only input is consumed as well.
 func EOS[T any]() Parser[T] {
 	return func(ctx *ParseContext[T], tokens []Token[T]) (int, []Token[T], error) {
 		if len(tokens) == 0 {
 			return 0, nil, nil
 		}
+		if len(tokens) == 1 && tokens[0].triviaHolder {
+			return 1, nil, nil
+		}
 		return 0, nil, ErrNotMatch
 	}
 }
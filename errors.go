@@ -1,20 +1,29 @@
 package parsercombinator
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 )
 
 type ParseError struct {
-	Parent error
-	Pos    *Pos
+	Parent    error
+	Pos       *Pos
+	RuleStack []string     // Names of the rules being parsed when the error was created, outermost first
+	Labels    []ErrorLabel // Secondary positions shown by FormatError
 }
 
 func (e ParseError) Error() string {
+	var message string
 	if e.Pos != nil {
-		return fmt.Sprintf("%s at %s", e.Parent.Error(), e.Pos.String())
+		message = fmt.Sprintf("%s at %s", e.Parent.Error(), e.Pos.String())
 	} else {
-		return e.Parent.Error()
+		message = e.Parent.Error()
 	}
+	if len(e.RuleStack) > 0 {
+		message += " (while parsing " + strings.Join(e.RuleStack, " > ") + ")"
+	}
+	return message
 }
 
 func (e ParseError) Unwrap() error {
@@ -36,27 +45,92 @@ var (
 	// Repeat, Or don't ignore this error
 	ErrCritical = fmt.Errorf("critical error")
 
+	// ErrCommitted means a parser failed after a Cut or Commit in the same sequence
+	//
+	// The sequence can't be anything else, so Repeat, Or don't ignore this error
+	ErrCommitted = fmt.Errorf("committed")
+
 	// ErrStackOverflow means the parser recursion depth exceeded the maximum limit
 	// This prevents infinite loops in recursive parsers
 	ErrStackOverflow = fmt.Errorf("stack overflow")
 )
 
+// NotMatchError is the Parent of the ParseError created by NewErrNotMatch.
+// It matches errors.Is(err, ErrNotMatch) and can be taken out with errors.As:
+//
+//	var nm *pc.NotMatchError
+//	if errors.As(err, &nm) {
+//		fmt.Println(nm.Expected, nm.Actual, nm.Pos)
+//	}
+//
+// The errors of Between, the list combinators and Permutation (unclosed or mismatched delimiters,
+// trailing separators, duplicate or missing clauses) are NotMatchErrors too. They have a Message
+// that describes the problem better than the expected and actual values.
+type NotMatchError struct {
+	Expected  []string // Expected values; several when failures at the same position are merged
+	Actual    string   // What was found instead ("EOF" at the end of input)
+	Pos       *Pos
+	RuleStack []string // Names of the rules being parsed, outermost first (nil if unknown)
+	Message   string   // Replaces the "expected: ..., actual: ..." part of the message when set
+
+	fallback bool // Created by Expected; replaces the failures of the other Or alternatives
+}
+
+// Error returns the message without the position; the enclosing ParseError appends it.
+func (e *NotMatchError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("%s: %s", ErrNotMatch, e.Message)
+	}
+	expected := joinLabels(e.Expected, "or")
+	if e.Actual != "" {
+		return fmt.Sprintf("%s expected: %s, actual: %s", ErrNotMatch, expected, e.Actual)
+	}
+	return fmt.Sprintf("%s expected: %s, but not", ErrNotMatch, expected)
+}
+
+func (e *NotMatchError) Unwrap() error {
+	return ErrNotMatch
+}
+
+// RepeatCountError is the Parent of the ParseError created by NewErrRepeatCount.
+// It matches errors.Is(err, ErrRepeatCount).
+type RepeatCountError struct {
+	Label  string
+	Min    int
+	Actual int
+}
+
+func (e *RepeatCountError) Error() string {
+	return fmt.Sprintf("%s expected count: %d, actual count: %d", ErrRepeatCount, e.Min, e.Actual)
+}
+
+func (e *RepeatCountError) Unwrap() error {
+	return ErrRepeatCount
+}
+
 func NewErrNotMatch(expected, actual string, pos *Pos) error {
-	var err error
-	if actual != "" {
-		err = fmt.Errorf("%w expected: %s, actual: %s", ErrNotMatch, expected, actual)
-	} else {
-		err = fmt.Errorf("%w expected: %s, but not", ErrNotMatch, expected)
+	return newErrNotMatch([]string{expected}, actual, pos)
+}
+
+// newErrNotMatch creates a not match error with several expected values ("a, b or c").
+func newErrNotMatch(expected []string, actual string, pos *Pos) error {
+	return &ParseError{
+		Parent: &NotMatchError{Expected: expected, Actual: actual, Pos: pos},
+		Pos:    pos,
 	}
+}
+
+// newErrNotMatchMessage creates a not match error with a message describing the problem.
+func newErrNotMatchMessage(message string, expected []string, actual string, pos *Pos) *ParseError {
 	return &ParseError{
-		Parent: err,
+		Parent: &NotMatchError{Expected: expected, Actual: actual, Pos: pos, Message: message},
 		Pos:    pos,
 	}
 }
 
 func NewErrRepeatCount(label string, expected, actual int, pos *Pos) error {
 	return &ParseError{
-		Parent: fmt.Errorf("%w expected count: %d, actual count: %d", ErrRepeatCount, expected, actual),
+		Parent: &RepeatCountError{Label: label, Min: expected, Actual: actual},
 		Pos:    pos,
 	}
 }
@@ -74,3 +148,47 @@ func NewErrStackOverflow(currentDepth, maxDepth int, pos *Pos) error {
 		Pos:    pos,
 	}
 }
+
+// CommittedError is the Parent of the ParseError created by NewErrCommitted.
+// It matches errors.Is(err, ErrCommitted) but not the sentinel of the original error,
+// so Or and Repeat don't backtrack over it. errors.As still reaches the original error:
+//
+//	var nm *pc.NotMatchError
+//	if errors.As(err, &nm) {
+//		fmt.Println(nm.Expected, nm.Actual) // what was expected after the cut
+//	}
+type CommittedError struct {
+	Err error // The original error
+}
+
+// Error returns the message of the original error without the position; the enclosing ParseError appends it.
+func (e *CommittedError) Error() string {
+	message := e.Err.Error()
+	var pe *ParseError
+	if errors.As(e.Err, &pe) {
+		message = pe.Parent.Error()
+	}
+	return fmt.Sprintf("%s: %s", ErrCommitted, message)
+}
+
+func (e *CommittedError) Unwrap() error {
+	return ErrCommitted
+}
+
+// As lets errors.As find the types in the original error (e.g. *NotMatchError).
+func (e *CommittedError) As(target any) bool {
+	return errors.As(e.Err, target)
+}
+
+// NewErrCommitted turns a backtrackable error into a non-backtrackable one, keeping its message,
+// position, rule stack and labels
+func NewErrCommitted(err error) error {
+	committed := &ParseError{Parent: &CommittedError{Err: err}}
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		committed.Pos = pe.Pos
+		committed.RuleStack = pe.RuleStack
+		committed.Labels = pe.Labels
+	}
+	return committed
+}
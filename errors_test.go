@@ -0,0 +1,47 @@
+package parsercombinator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestNotMatchError(t *testing.T) {
+	pc := NewParseContext[string]()
+	parser := Seq(rawLiteral("x"), Or(OneOfLiterals[string]("+", "-"), rawLiteral("number")))
+	tokens := []Token[string]{
+		{Type: "raw", Raw: "x", Pos: &Pos{Line: 1, Col: 1, Length: 1}},
+		{Type: "raw", Raw: "*", Pos: &Pos{Line: 1, Col: 3, Index: 2, Length: 1}},
+	}
+	_, err := Evaluate(pc, tokens, parser)
+	assert.True(t, errors.Is(err, ErrNotMatch))
+
+	var nm *NotMatchError
+	assert.True(t, errors.As(err, &nm))
+	assert.Equal(t, []string{"'+'", "'-'", "number"}, nm.Expected)
+	assert.Equal(t, "'*'", nm.Actual)
+	assert.Equal(t, &Pos{Line: 1, Col: 3, Index: 2, Length: 1}, nm.Pos)
+	assert.Equal(t, "not match expected: '+', '-' or number, actual: '*' at 1:3", err.Error())
+
+	// errors created by NewErrNotMatch have a single expected value
+	err = NewErrNotMatch("identifier", "EOF", nil)
+	assert.True(t, errors.As(err, &nm))
+	assert.Equal(t, []string{"identifier"}, nm.Expected)
+	assert.Equal(t, "not match expected: identifier, actual: EOF", err.Error())
+}
+
+func TestRepeatCountError(t *testing.T) {
+	pc := NewParseContext[string]()
+	_, err := EvaluateWithRawTokens(pc, strings.Fields("a a b"), Repeat("items", 3, -1, rawLiteral("a")))
+	assert.True(t, errors.Is(err, ErrRepeatCount))
+
+	var rc *RepeatCountError
+	assert.True(t, errors.As(err, &rc))
+	assert.Equal(t, &RepeatCountError{Label: "items", Min: 3, Actual: 2}, rc)
+	assert.Equal(t, "repeat count expected count: 3, actual count: 2 at 0 (while parsing items)", err.Error())
+
+	var nm *NotMatchError
+	assert.False(t, errors.As(err, &nm))
+}
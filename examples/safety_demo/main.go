@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 
 	pc "github.com/shibukawa/parsercombinator"
@@ -64,6 +65,7 @@ func demoTransformationSafety() {
 	unsafeContext := pc.NewParseContext[int]()
 	unsafeContext.OrMode = pc.OrModeSafe
 	unsafeContext.CheckTransformSafety = true
+	unsafeContext.DiagnosticSink = pc.WriterSink(os.Stderr)
 
 	unsafeParser := pc.Trans(digitParser, func(pctx *pc.ParseContext[int], tokens []pc.Token[int]) ([]pc.Token[int], error) {
 		// Return same tokens - potentially unsafe!
@@ -139,6 +141,7 @@ func demoOrParserModes() {
 	fmt.Println("  TryFast mode (first match with optimization warning):")
 	tryFastContext := pc.NewParseContext[string]()
 	tryFastContext.OrMode = pc.OrModeTryFast
+	tryFastContext.DiagnosticSink = pc.WriterSink(os.Stderr)
 
 	result, err = pc.EvaluateWithRawTokens(tryFastContext, input, orParser)
 	if err != nil {
@@ -0,0 +1,263 @@
+package parsercombinator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Fixity defines where an operator is written relative to its operands
+type Fixity int
+
+const (
+	// Prefix operators precede their operand (-a, !a)
+	Prefix Fixity = iota
+	// Infix operators are written between two operands (a + b)
+	Infix
+	// Postfix operators follow their operand (a!, a++)
+	Postfix
+)
+
+func (f Fixity) String() string {
+	switch f {
+	case Prefix:
+		return "Prefix"
+	case Infix:
+		return "Infix"
+	case Postfix:
+		return "Postfix"
+	default:
+		return "Unknown"
+	}
+}
+
+// Associativity defines how chains of infix operators with the same power are grouped
+type Associativity int
+
+const (
+	// AssocLeft groups a - b - c as (a - b) - c
+	AssocLeft Associativity = iota
+	// AssocRight groups a ^ b ^ c as a ^ (b ^ c)
+	AssocRight
+	// AssocNone rejects chains like a < b < c
+	AssocNone
+)
+
+func (a Associativity) String() string {
+	switch a {
+	case AssocLeft:
+		return "Left"
+	case AssocRight:
+		return "Right"
+	case AssocNone:
+		return "None"
+	default:
+		return "Unknown"
+	}
+}
+
+// Operator is one entry of the operator table of ExpressionParser.
+// Operators with a higher Power bind tighter.
+type Operator[T any] struct {
+	Fixity Fixity
+	Op     Parser[T]
+	Power  int
+	Assoc  Associativity // Used by infix operators only
+}
+
+// PrefixOp creates a prefix operator table entry.
+func PrefixOp[T any](op Parser[T], power int) Operator[T] {
+	return Operator[T]{Fixity: Prefix, Op: op, Power: power}
+}
+
+// InfixOp creates an infix operator table entry.
+func InfixOp[T any](op Parser[T], power int, assoc Associativity) Operator[T] {
+	return Operator[T]{Fixity: Infix, Op: op, Power: power, Assoc: assoc}
+}
+
+// PostfixOp creates a postfix operator table entry.
+func PostfixOp[T any](op Parser[T], power int) Operator[T] {
+	return Operator[T]{Fixity: Postfix, Op: op, Power: power}
+}
+
+// NodeBuilder creates the token for an operator application.
+// operands has one element for prefix and postfix operators and two (left, right) for infix operators.
+type NodeBuilder[T any] func(pctx *ParseContext[T], op Token[T], operands []Token[T]) (Token[T], error)
+
+// ExpressionParser creates an operator-precedence (Pratt) parser.
+//
+// operand parses the atoms of the expression (numbers, identifiers, parenthesized expressions)
+// and must return exactly one token. The operators are matched at each position in table order
+// and build combines every operator application into a single token:
+//
+//	expr := ExpressionParser("expr", operand, []Operator[T]{
+//		InfixOp(Literal[T]("+"), 10, AssocLeft),
+//		InfixOp(Literal[T]("*"), 20, AssocLeft),
+//		PrefixOp(Literal[T]("-"), 30),
+//	}, build)
+//
+// Chaining non-associative operators of the same power (a < b < c) is reported as a critical error,
+// and so is a prefix or postfix operator that matches without consuming a token.
+func ExpressionParser[T any](label string, operand Parser[T], operators []Operator[T], build NodeBuilder[T]) Parser[T] {
+	var prefix, infix, postfix []Operator[T]
+	for _, op := range operators {
+		switch op.Fixity {
+		case Prefix:
+			prefix = append(prefix, op)
+		case Infix:
+			infix = append(infix, op)
+		case Postfix:
+			postfix = append(postfix, op)
+		}
+	}
+	e := &expressionParser[T]{operand: operand, prefix: prefix, infix: infix, postfix: postfix, build: build}
+	return Trace(label, func(pctx *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		consumed, result, err := e.parse(pctx, src, 0)
+		if err != nil {
+			return 0, nil, err
+		}
+		return consumed, []Token[T]{result}, nil
+	})
+}
+
+type expressionParser[T any] struct {
+	operand Parser[T]
+	prefix  []Operator[T]
+	infix   []Operator[T]
+	postfix []Operator[T]
+	build   NodeBuilder[T]
+}
+
+// parse implements Pratt parsing with binding powers derived from Operator.Power:
+// left-binding power 2p and right-binding power 2p+1 (left/non-associative) or 2p (right-associative).
+func (e *expressionParser[T]) parse(pctx *ParseContext[T], src []Token[T], minPower int) (int, Token[T], error) {
+	var left Token[T]
+	offset := 0
+
+	if op, opToken, consumed, err := matchOperator(pctx, src, e.prefix); err != nil {
+		return 0, left, err
+	} else if op != nil {
+		if consumed == 0 {
+			// an operator that consumes nothing would be applied forever
+			return 0, left, NewErrCritical("prefix operator must consume a token", getFirstPos(src))
+		}
+		rest := src[consumed:]
+		operandConsumed, operand, err := e.parse(pctx, rest, 2*op.Power)
+		if err != nil {
+			return 0, left, err
+		}
+		if left, err = e.build(pctx, opToken, []Token[T]{operand}); err != nil {
+			return 0, left, err
+		}
+		offset = consumed + operandConsumed
+	} else {
+		consumed, newTokens, err := e.operand(pctx, src)
+		if err != nil {
+			return 0, left, err
+		}
+		if left, err = singleOperand(newTokens, src); err != nil {
+			return 0, left, err
+		}
+		offset = consumed
+	}
+
+	var lastNonAssoc *Operator[T]
+	var lastNonAssocToken Token[T]
+	for offset < len(src) {
+		rest := src[offset:]
+		op, opToken, consumed, err := matchOperator(pctx, rest, e.postfix)
+		if err != nil {
+			return 0, left, err
+		}
+		if op != nil {
+			if consumed == 0 {
+				return 0, left, NewErrCritical("postfix operator must consume a token", getFirstPos(rest))
+			}
+			if 2*op.Power < minPower {
+				break
+			}
+			if left, err = e.build(pctx, opToken, []Token[T]{left}); err != nil {
+				return 0, left, err
+			}
+			offset += consumed
+			lastNonAssoc = nil
+			continue
+		}
+
+		op, opToken, consumed, err = matchOperator(pctx, rest, e.infix)
+		if err != nil {
+			return 0, left, err
+		}
+		if op == nil || 2*op.Power < minPower {
+			break
+		}
+		if op.Assoc == AssocNone && lastNonAssoc != nil && lastNonAssoc.Power == op.Power {
+			return 0, left, NewErrCritical(fmt.Sprintf("non-associative operator %s cannot be chained with %s",
+				describeToken(opToken), describeToken(lastNonAssocToken)), opToken.Pos)
+		}
+		rightPower := 2*op.Power + 1
+		if op.Assoc == AssocRight {
+			rightPower = 2 * op.Power
+		}
+		rightConsumed, right, err := e.parse(pctx, rest[consumed:], rightPower)
+		if err != nil {
+			return 0, left, err
+		}
+		if left, err = e.build(pctx, opToken, []Token[T]{left, right}); err != nil {
+			return 0, left, err
+		}
+		offset += consumed + rightConsumed
+		if op.Assoc == AssocNone {
+			lastNonAssoc, lastNonAssocToken = op, opToken
+		} else {
+			lastNonAssoc = nil
+		}
+	}
+	return offset, left, nil
+}
+
+// matchOperator returns the operator that consumes the most tokens at the head of src (first one on a tie).
+func matchOperator[T any](pctx *ParseContext[T], src []Token[T], operators []Operator[T]) (*Operator[T], Token[T], int, error) {
+	var best *Operator[T]
+	var bestToken Token[T]
+	bestConsumed := 0
+	if len(src) == 0 {
+		return nil, bestToken, 0, nil
+	}
+	saved := pctx.snapshot()
+	bestSaved := saved
+	for i := range operators {
+		pctx.restore(saved)
+		consumed, newTokens, err := operators[i].Op(pctx, src)
+		if err != nil {
+			if errors.Is(err, ErrNotMatch) || errors.Is(err, ErrRepeatCount) {
+				pctx.noteFailure(err)
+				continue
+			}
+			return nil, bestToken, 0, err
+		}
+		if best == nil || consumed > bestConsumed {
+			best = &operators[i]
+			bestConsumed = consumed
+			bestToken = operatorToken(src, newTokens)
+			bestSaved = pctx.snapshot()
+		}
+	}
+	pctx.restore(bestSaved)
+	return best, bestToken, bestConsumed, nil
+}
+
+// singleOperand returns the only token an operand parser produced.
+func singleOperand[T any](newTokens, src []Token[T]) (Token[T], error) {
+	if len(newTokens) != 1 {
+		return Token[T]{}, NewErrCritical(fmt.Sprintf("operand must produce exactly one token, but produced %d", len(newTokens)), getFirstPos(src))
+	}
+	return newTokens[0], nil
+}
+
+// operatorToken returns the token an operator parser produced, or the first matched source token.
+func operatorToken[T any](src, newTokens []Token[T]) Token[T] {
+	if len(newTokens) == 1 {
+		return newTokens[0]
+	}
+	return src[0]
+}
@@ -0,0 +1,138 @@
+package parsercombinator
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+// exprTestParser builds an expression parser whose tokens carry the expression in prefix notation as Raw.
+func exprTestParser() Parser[string] {
+	var expr Parser[string]
+	number := Trans(TokenType[string]("number"), func(pc *ParseContext[string], src []Token[string]) ([]Token[string], error) {
+		return []Token[string]{{Type: "expr", Pos: src[0].Pos, Val: src[0].Raw}}, nil
+	})
+	operand := Or(number, Between(Literal[string]("("), Literal[string](")"), Lazy(func() Parser[string] { return expr })))
+	build := func(pc *ParseContext[string], op Token[string], operands []Token[string]) (Token[string], error) {
+		val := "(" + op.Raw
+		for _, o := range operands {
+			val += " " + o.Val
+		}
+		return Token[string]{Type: "expr", Pos: op.Pos, Val: val + ")"}, nil
+	}
+	expr = ExpressionParser("expr", operand, []Operator[string]{
+		InfixOp(Literal[string]("<"), 5, AssocNone),
+		InfixOp(Literal[string](">"), 5, AssocNone),
+		InfixOp(Literal[string]("=="), 4, AssocNone),
+		InfixOp(Literal[string]("+"), 10, AssocLeft),
+		InfixOp(Literal[string]("-"), 10, AssocLeft),
+		InfixOp(Literal[string]("*"), 20, AssocLeft),
+		InfixOp(Literal[string]("^"), 40, AssocRight),
+		PrefixOp(Literal[string]("-"), 30),
+		PostfixOp(Literal[string]("!"), 50),
+	}, build)
+	return expr
+}
+
+func TestExpressionParser(t *testing.T) {
+	lexer, err := NewLexer[string](
+		SkipRule(`\s+`),
+		RegexpRule("number", `[0-9]+`),
+		RegexpRule("op", `==|[-+*^<>!()]`),
+	)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		src      string
+		want     string
+		consumed int
+		wantErr  string
+	}{
+		{src: "1", want: "1"},
+		{src: "1 + 2 * 3", want: "(+ 1 (* 2 3))"},
+		{src: "1 * 2 + 3", want: "(+ (* 1 2) 3)"},
+		{src: "1 - 2 - 3", want: "(- (- 1 2) 3)"},
+		{src: "2 ^ 3 ^ 4", want: "(^ 2 (^ 3 4))"},
+		{src: "- 1 + 2", want: "(+ (- 1) 2)"},
+		{src: "- 2 ^ 2", want: "(- (^ 2 2))"},
+		{src: "- - 1", want: "(- (- 1))"},
+		{src: "- 3 !", want: "(- (! 3))"},
+		{src: "3 ! ! * 2", want: "(* (! (! 3)) 2)"},
+		{src: "(1 + 2) * 3", want: "(* (+ 1 2) 3)"},
+		{src: "1 < 2 == 3 > 4", want: "(== (< 1 2) (> 3 4))"},
+		{src: "1 + 2 )", want: "(+ 1 2)", consumed: 3},
+		{src: "1 < 2 < 3", wantErr: "critical error: non-associative operator '<' cannot be chained with '<' at 1:7"},
+		{src: "1 < 2 > 3", wantErr: "critical error: non-associative operator '>' cannot be chained with '<' at 1:7"},
+		{src: "(1 < 2) < 3", want: "(< (< 1 2) 3)"},
+		{src: "1 +", wantErr: "not match expected: number, actual: EOF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			tokens, err := lexer.Tokenize(tt.src)
+			assert.NoError(t, err)
+			pc := NewParseContext[string]()
+			consumed, result, err := exprTestParser()(pc, tokens)
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.consumed == 0 {
+				tt.consumed = len(tokens)
+			}
+			assert.Equal(t, tt.consumed, consumed)
+			assert.Equal(t, 1, len(result))
+			assert.Equal(t, tt.want, result[0].Val)
+		})
+	}
+}
+
+var digitsPattern = regexp.MustCompile(`^[0-9]+$`)
+
+func TestExpressionParserErrors(t *testing.T) {
+	operand := Trans(Literal[int]("x"), func(pc *ParseContext[int], src []Token[int]) ([]Token[int], error) {
+		return []Token[int]{src[0], src[0]}, nil
+	})
+	build := func(pc *ParseContext[int], op Token[int], operands []Token[int]) (Token[int], error) {
+		return Token[int]{}, fmt.Errorf("build failed")
+	}
+	pc := NewParseContext[int]()
+	_, err := EvaluateWithRawTokens(pc, []string{"x"}, ExpressionParser("expr", operand, nil, build))
+	assert.True(t, errors.Is(err, ErrCritical))
+
+	number := Trans(RawRegexp[int](digitsPattern), func(pc *ParseContext[int], src []Token[int]) ([]Token[int], error) {
+		v, err := strconv.Atoi(src[0].Raw)
+		return []Token[int]{{Type: "number", Pos: src[0].Pos, Val: v}}, err
+	})
+	_, err = EvaluateWithRawTokens(pc, []string{"1", "+", "2"}, ExpressionParser("expr", number, []Operator[int]{InfixOp(Literal[int]("+"), 1, AssocLeft)}, build))
+	assert.EqualError(t, err, "build failed at 0")
+}
+
+func TestFixityAndAssociativityString(t *testing.T) {
+	assert.Equal(t, "Prefix", Prefix.String())
+	assert.Equal(t, "Infix", Infix.String())
+	assert.Equal(t, "Postfix", Postfix.String())
+	assert.Equal(t, "Left", AssocLeft.String())
+	assert.Equal(t, "Right", AssocRight.String())
+	assert.Equal(t, "None", AssocNone.String())
+}
+
+func TestExpressionParserZeroLengthOperator(t *testing.T) {
+	build := func(pc *ParseContext[string], op Token[string], operands []Token[string]) (Token[string], error) {
+		return operands[0], nil
+	}
+	empty := Optional(Literal[string]("!"))
+	pc := NewParseContext[string]()
+	for _, op := range []Operator[string]{PostfixOp(empty, 50), PrefixOp(empty, 50)} {
+		expr := ExpressionParser("expr", rawLiteral("x"), []Operator[string]{op}, build)
+		_, err := EvaluateWithRawTokens(pc, []string{"x", "y"}, expr)
+		assert.True(t, errors.Is(err, ErrCritical))
+		assert.Contains(t, err.Error(), fmt.Sprintf("%s operator must consume a token", strings.ToLower(op.Fixity.String())))
+	}
+}
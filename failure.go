@@ -0,0 +1,182 @@
+package parsercombinator
+
+import (
+	"errors"
+	"slices"
+)
+
+// farthestFailure collects the not match errors at the farthest position any parser reached.
+type farthestFailure struct {
+	found     bool
+	pos       *Pos
+	expected  []string      // Merged expected values of NewErrNotMatch errors
+	actual    string        // Actual value of the first NewErrNotMatch error
+	ruleStack []string      // Rule stack of the first NewErrNotMatch error
+	messages  []*ParseError // Other not match errors (e.g. "unclosed '(' opened")
+}
+
+// FarthestFailure returns the not match error at the farthest position reached by the last
+// Evaluate, with the expected values of all parsers that failed there merged into one message
+// ("not match expected: identifier, '(' or number, actual: '}' at 12:4"). It returns nil
+// when no parser failed.
+//
+// Failures are collected from the alternatives of Or and from the attempts that end Optional,
+// Repeat and the list and expression combinators, so the result also reports what could have
+// continued a sequence. Other not match errors (like "unclosed '(' opened") take precedence
+// over the merged message at the same position.
+func (pc *ParseContext[T]) FarthestFailure() error {
+	f := &pc.farthest
+	if !f.found {
+		return nil
+	}
+	if len(f.messages) > 0 {
+		return f.messages[0]
+	}
+	err := newErrNotMatch(f.expected, f.actual, f.pos).(*ParseError)
+	err.RuleStack = f.ruleStack
+	err.Parent.(*NotMatchError).RuleStack = f.ruleStack
+	return err
+}
+
+// noteFailure records the not match errors in err (including the errors joined by Or).
+func (pc *ParseContext[T]) noteFailure(err error) {
+	pc.farthest.note(err)
+}
+
+func (f *farthestFailure) note(err error) {
+	var pe *ParseError
+	switch e := err.(type) {
+	case *ParseError:
+		pe = e
+	case interface{ Unwrap() []error }:
+		for _, child := range e.Unwrap() {
+			f.note(child)
+		}
+		return
+	default:
+		return
+	}
+	if pe.Parent == errLeftRecursionSeed {
+		return
+	}
+	if nm, ok := pe.Parent.(*NotMatchError); ok && nm.Message == "" {
+		if f.reach(pe.Pos) {
+			if len(f.expected) == 0 {
+				f.actual = nm.Actual
+				f.ruleStack = pe.RuleStack
+			}
+			for _, expected := range nm.Expected {
+				if !slices.Contains(f.expected, expected) {
+					f.expected = append(f.expected, expected)
+				}
+			}
+		}
+		return
+	}
+	var nested *ParseError
+	if errors.As(pe.Parent, &nested) {
+		f.note(pe.Parent)
+		return
+	}
+	if errors.Is(pe, ErrNotMatch) && f.reach(pe.Pos) {
+		f.messages = append(f.messages, pe)
+	}
+}
+
+// clone returns a copy of f that later notes to f don't modify.
+func (f farthestFailure) clone() farthestFailure {
+	f.expected = slices.Clip(f.expected)
+	f.messages = slices.Clip(f.messages)
+	return f
+}
+
+// merge adds the failures collected in other.
+func (f *farthestFailure) merge(other farthestFailure) {
+	if !other.found || !f.reach(other.pos) {
+		return
+	}
+	if len(f.expected) == 0 {
+		f.actual = other.actual
+		f.ruleStack = other.ruleStack
+	}
+	for _, expected := range other.expected {
+		if !slices.Contains(f.expected, expected) {
+			f.expected = append(f.expected, expected)
+		}
+	}
+	f.messages = append(f.messages, other.messages...)
+}
+
+// expectedFallback returns the error of an Expected fallback among the alternatives joined in err.
+func expectedFallback(err error) error {
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		return nil
+	}
+	joined, ok := pe.Parent.(interface{ Unwrap() []error })
+	if !ok {
+		return nil
+	}
+	for _, child := range joined.Unwrap() {
+		var nm *NotMatchError
+		if errors.As(child, &nm) && nm.fallback {
+			return child
+		}
+	}
+	return nil
+}
+
+// expectedAt returns the expected values of the not match error parser reports at src.
+// The parser runs without side effects on the context.
+func expectedAt[T any](pctx *ParseContext[T], parser Parser[T], src []Token[T]) []string {
+	saved, farthest := pctx.snapshot(), pctx.farthest.clone()
+	_, _, err := parser(pctx, src)
+	pctx.restore(saved)
+	pctx.farthest = farthest
+	var nm *NotMatchError
+	if err != nil && errors.As(err, &nm) {
+		return nm.Expected
+	}
+	return nil
+}
+
+// reach moves the farthest position to pos if it is farther and reports whether pos is the
+// farthest position now.
+func (f *farthestFailure) reach(pos *Pos) bool {
+	if !f.found {
+		*f = farthestFailure{found: true, pos: pos}
+		return true
+	}
+	switch c := comparePos(pos, f.pos); {
+	case c > 0:
+		*f = farthestFailure{found: true, pos: pos}
+		return true
+	case c == 0:
+		return true
+	default:
+		return false
+	}
+}
+
+// comparePos orders positions by file and index. A nil position means the end of input.
+func comparePos(a, b *Pos) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	if a.File != b.File {
+		return fileID(a.File) - fileID(b.File)
+	}
+	return a.Index - b.Index
+}
+
+func fileID(file *Source) int {
+	if file == nil {
+		return -1
+	}
+	return file.ID
+}
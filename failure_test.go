@@ -0,0 +1,138 @@
+package parsercombinator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestFarthestFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		parser  Parser[string]
+		src     string
+		wantErr string
+	}{
+		{
+			name:    "or alternatives are merged",
+			parser:  Seq(rawLiteral("x"), rawLiteral("="), Or(rawLiteral("identifier"), Literal[string]("("), rawLiteral("number"))),
+			src:     "x = }",
+			wantErr: "not match expected: identifier, '(' or number, actual: } at 2",
+		},
+		{
+			name: "farther alternative wins",
+			parser: Or(
+				Seq(rawLiteral("a"), rawLiteral("b"), rawLiteral("c")),
+				Seq(rawLiteral("a"), rawLiteral("x")),
+				rawLiteral("z"),
+			),
+			src:     "a b d",
+			wantErr: "not match expected: c, actual: d at 2",
+		},
+		{
+			name:    "attempts ending a repetition",
+			parser:  Seq(ZeroOrMore("items", rawLiteral("a")), rawLiteral("b")),
+			src:     "a a c",
+			wantErr: "not match expected: a or b, actual: c at 2",
+		},
+		{
+			name:    "failure inside optional",
+			parser:  Seq(Optional(Seq(rawLiteral("a"), rawLiteral("b"))), rawLiteral("c")),
+			src:     "a x",
+			wantErr: "not match expected: b, actual: x at 1",
+		},
+		{
+			name:    "end of input",
+			parser:  Or(Seq(rawLiteral("a"), rawLiteral("b")), Seq(rawLiteral("a"), rawLiteral("c"))),
+			src:     "a",
+			wantErr: "not match expected: b or c, actual: EOF",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := NewParseContext[string]()
+			_, err := EvaluateWithRawTokens(pc, strings.Fields(tt.src), tt.parser)
+			assert.EqualError(t, err, tt.wantErr)
+			assert.True(t, errors.Is(err, ErrNotMatch))
+			assert.EqualError(t, pc.FarthestFailure(), tt.wantErr)
+		})
+	}
+}
+
+func TestFarthestFailureKeepsOtherErrors(t *testing.T) {
+	pc := NewParseContext[string]()
+	_, err := EvaluateWithRawTokens(pc, []string{"a"}, rawLiteral("a"))
+	assert.NoError(t, err)
+	assert.NoError(t, pc.FarthestFailure())
+
+	// critical errors are reported as is
+	_, err = EvaluateWithRawTokens(pc, []string{"a", "b"}, Seq(Optional(rawLiteral("x")), rawLiteral("a"), Fail[string]("broken")))
+	assert.EqualError(t, err, "critical error: broken at 1")
+
+	// specific not match messages win over merged expected values at the same position
+	parser := Seq(rawLiteral("("), Between(Literal[string]("["), Literal[string]("]"), rawLiteral("x")))
+	_, err = EvaluateWithRawTokens(pc, []string{"(", "[", "x"}, parser)
+	assert.EqualError(t, err, "not match: unclosed '[' opened at 1")
+
+	// positions in different files are ordered by the file registration order
+	sources := NewSourceSet()
+	first, second := sources.Add("a.txt", "a"), sources.Add("b.txt", "b")
+	assert.True(t, comparePos(&Pos{Index: 10, File: first}, &Pos{Index: 0, File: second}) < 0)
+	assert.True(t, comparePos(nil, &Pos{Index: 0, File: second}) > 0)
+}
+
+func TestFarthestFailureWithoutParseError(t *testing.T) {
+	// EOS and user parsers may return not match errors that are not *ParseError
+	pc := NewParseContext[string]()
+	_, err := EvaluateWithRawTokens(pc, []string{"x", "y"}, Seq(rawLiteral("x"), EOS[string]()))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNotMatch))
+	assert.EqualError(t, err, "not match at 0")
+
+	wrapped := func(pctx *ParseContext[string], src []Token[string]) (int, []Token[string], error) {
+		return 0, nil, fmt.Errorf("%w: custom", ErrNotMatch)
+	}
+	_, err = EvaluateWithRawTokens(pc, []string{"x"}, Parser[string](wrapped))
+	assert.True(t, errors.Is(err, ErrNotMatch))
+	assert.EqualError(t, err, "not match: custom at 0")
+}
+
+func TestFarthestFailureLabelAndExpected(t *testing.T) {
+	pc := NewParseContext[string]()
+
+	// Label replaces the expected values of the failures inside it
+	value := Label("value", Or(Literal[string]("a"), Literal[string]("b")))
+	_, err := EvaluateWithRawTokens(pc, []string{"z"}, value)
+	assert.EqualError(t, err, "not match expected: value, actual: 'z' at 0 (while parsing value)")
+
+	// unless the labeled parser got farther
+	call := Label("call", Seq(rawLiteral("f"), Literal[string]("(")))
+	_, err = EvaluateWithRawTokens(pc, []string{"f", "z"}, call)
+	assert.EqualError(t, err, "not match expected: '(', actual: 'z' at 1 (while parsing call)")
+
+	// Expected fallbacks don't merge with the other alternatives
+	statement := Or(Literal[string]("x"), Expected[string]("statement"))
+	_, err = EvaluateWithRawTokens(pc, []string{"z"}, statement)
+	assert.EqualError(t, err, "not match expected: statement, actual: 'z' at 0")
+	fallback := Or(Seq(rawLiteral("a"), rawLiteral("b")), Expected[string]("statement"))
+	_, err = EvaluateWithRawTokens(pc, []string{"a"}, fallback)
+	assert.EqualError(t, err, "not match expected: statement, actual: 'a' at 0")
+}
+
+func TestFarthestFailureIgnoresLookahead(t *testing.T) {
+	pc := NewParseContext[string]()
+	parser := Seq(
+		NotFollowedBy(Seq(rawLiteral("a"), Or(Literal[string]("("), Literal[string]("[")))),
+		rawLiteral("a"),
+		Literal[string]("="),
+	)
+	_, err := EvaluateWithRawTokens(pc, []string{"a", "x"}, parser)
+	assert.EqualError(t, err, "not match expected: '=', actual: 'x' at 1")
+
+	parser = Seq(Lookahead(Or(rawLiteral("a"), Seq(rawLiteral("b"), rawLiteral("c")))), Or(rawLiteral("a"), rawLiteral("b")), rawLiteral("="))
+	_, err = EvaluateWithRawTokens(pc, []string{"a", "x"}, parser)
+	assert.EqualError(t, err, "not match expected: =, actual: x at 1")
+}
@@ -0,0 +1,241 @@
+package parsercombinator
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrorLabel marks a secondary position of an error, like the opening bracket of an unclosed block.
+type ErrorLabel struct {
+	Pos     *Pos
+	Message string
+}
+
+// ErrorFormatOptions configures FormatError.
+type ErrorFormatOptions struct {
+	Path  string // File name shown for positions without Pos.File
+	Color bool   // Colorize the output with ANSI escape sequences
+}
+
+const (
+	colorReset     = "\x1b[0m"
+	colorError     = "\x1b[1;31m"
+	colorSecondary = "\x1b[1;34m"
+	colorBold      = "\x1b[1m"
+)
+
+// FormatError renders err (a ParseError or the joined errors returned by Evaluate) as
+// source snippets:
+//
+//	error: not match expected: ')', actual: 'b'
+//	  --> calc.txt:1:5
+//	   |
+//	 1 | f(a b)
+//	   |  - '(' opened here
+//	   |     ^
+//
+// The line is taken from Pos.File.Text, or from source for positions without a file.
+// The primary position is underlined with carets spanning Pos.Length and the ErrorLabels of
+// the error with dashes. Errors without a line/column position are printed as messages only.
+func FormatError(source string, err error, opts ErrorFormatOptions) string {
+	builder := &strings.Builder{}
+	f := errorFormatter{source: source, opts: opts, w: builder}
+	for i, e := range flattenErrors(err) {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		f.format(e)
+	}
+	return builder.String()
+}
+
+// flattenErrors splits the errors joined by Evaluate (but not the ones inside a ParseError).
+func flattenErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*ParseError); !ok {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			var result []error
+			for _, e := range joined.Unwrap() {
+				result = append(result, flattenErrors(e)...)
+			}
+			return result
+		}
+	}
+	return []error{err}
+}
+
+type errorFormatter struct {
+	source string
+	opts   ErrorFormatOptions
+	w      *strings.Builder
+}
+
+type snippetMark struct {
+	pos     *Pos
+	primary bool
+	message string
+}
+
+func (f *errorFormatter) paint(color, text string) string {
+	if !f.opts.Color {
+		return text
+	}
+	return color + text + colorReset
+}
+
+func (f *errorFormatter) format(err error) {
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		fmt.Fprintf(f.w, "%s %s\n", f.paint(colorError, "error:"), f.paint(colorBold, err.Error()))
+		return
+	}
+	fmt.Fprintf(f.w, "%s %s\n", f.paint(colorError, "error:"), f.paint(colorBold, pe.Parent.Error()))
+
+	marks := []snippetMark{{pos: pe.Pos, primary: true}}
+	for _, label := range pe.Labels {
+		marks = append(marks, snippetMark{pos: label.Pos, message: label.Message})
+	}
+	marks = slices.DeleteFunc(marks, func(m snippetMark) bool {
+		return m.pos == nil || m.pos.Line == 0
+	})
+
+	gutter := 1
+	for _, m := range marks {
+		gutter = max(gutter, len(strconv.Itoa(m.pos.Line)))
+	}
+	indent := strings.Repeat(" ", gutter)
+	bar := f.paint(colorSecondary, "|")
+
+	if pe.Pos != nil {
+		fmt.Fprintf(f.w, "%s%s %s\n", indent, f.paint(colorSecondary, "-->"), f.location(pe.Pos))
+	}
+	if len(marks) > 0 {
+		fmt.Fprintf(f.w, "%s %s\n", indent, bar)
+	}
+	// group the marks by file and line, in source order
+	slices.SortStableFunc(marks, func(a, b snippetMark) int {
+		return comparePos(a.pos, b.pos)
+	})
+	for i := 0; i < len(marks); {
+		j := i + 1
+		for j < len(marks) && marks[j].pos.File == marks[i].pos.File && marks[j].pos.Line == marks[i].pos.Line {
+			j++
+		}
+		if i > 0 && marks[i].pos.File != marks[i-1].pos.File {
+			fmt.Fprintf(f.w, "%s%s %s\n", indent, f.paint(colorSecondary, "::"), f.location(marks[i].pos))
+		}
+		f.snippet(marks[i:j], gutter, bar)
+		i = j
+	}
+	if len(pe.RuleStack) > 0 {
+		fmt.Fprintf(f.w, "%s %s note: while parsing %s\n", indent, f.paint(colorSecondary, "="), strings.Join(pe.RuleStack, " > "))
+	}
+}
+
+func (f *errorFormatter) location(pos *Pos) string {
+	if pos.File == nil && f.opts.Path != "" {
+		return f.opts.Path + ":" + pos.String()
+	}
+	return pos.String()
+}
+
+// snippet prints one source line and a marker line for each mark on it.
+func (f *errorFormatter) snippet(marks []snippetMark, gutter int, bar string) {
+	text := f.source
+	if marks[0].pos.File != nil {
+		text = marks[0].pos.File.Text
+	}
+	line, ok := sourceLine(text, marks[0].pos.Line)
+	if !ok {
+		return
+	}
+	fmt.Fprintf(f.w, "%*d %s %s\n", gutter, marks[0].pos.Line, bar, line)
+	for _, m := range marks {
+		prefix, width := markerSpan(line, m.pos.Col, m.pos.Length)
+		marker, color := "-", colorSecondary
+		if m.primary {
+			marker, color = "^", colorError
+		}
+		underline := strings.Repeat(marker, width)
+		if m.message != "" {
+			underline += " " + m.message
+		}
+		fmt.Fprintf(f.w, "%s %s %s%s\n", strings.Repeat(" ", gutter), bar, prefix, f.paint(color, underline))
+	}
+}
+
+// sourceLine returns the 1-based line of text without the line break.
+func sourceLine(text string, line int) (string, bool) {
+	for i := 1; i < line; i++ {
+		next := strings.IndexByte(text, '\n')
+		if next < 0 {
+			return "", false
+		}
+		text = text[next+1:]
+	}
+	if end := strings.IndexByte(text, '\n'); end >= 0 {
+		text = text[:end]
+	}
+	return strings.TrimSuffix(text, "\r"), true
+}
+
+// markerSpan returns the whitespace that aligns a marker with the 1-based column col of line
+// (keeping tabs) and the marker width for length bytes, at least 1. Both are measured in display
+// columns, so wide characters (CJK, fullwidth forms, emoji) count as two.
+func markerSpan(line string, col, length int) (string, int) {
+	prefix := strings.Builder{}
+	start := 0
+	for i := 1; i < col && start < len(line); i++ {
+		r, size := utf8.DecodeRuneInString(line[start:])
+		if r == '\t' {
+			prefix.WriteByte('\t')
+		} else {
+			prefix.WriteString(strings.Repeat(" ", runeWidth(r)))
+		}
+		start += size
+	}
+	end := min(start+length, len(line))
+	width := 0
+	for _, r := range line[start:end] {
+		width += runeWidth(r)
+	}
+	return prefix.String(), max(1, width)
+}
+
+// wideRanges are the East Asian Wide and Fullwidth ranges that terminals draw two columns wide.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115f},   // Hangul Jamo
+	{0x2e80, 0x303e},   // CJK radicals, Kangxi, CJK symbols and punctuation
+	{0x3041, 0x33ff},   // Hiragana, Katakana, Bopomofo, CJK compatibility
+	{0x3400, 0x4dbf},   // CJK Unified Ideographs Extension A
+	{0x4e00, 0x9fff},   // CJK Unified Ideographs
+	{0xa000, 0xa4cf},   // Yi
+	{0xac00, 0xd7a3},   // Hangul syllables
+	{0xf900, 0xfaff},   // CJK compatibility ideographs
+	{0xfe30, 0xfe4f},   // CJK compatibility forms
+	{0xff00, 0xff60},   // Fullwidth forms
+	{0xffe0, 0xffe6},   // Fullwidth signs
+	{0x1f300, 0x1f64f}, // Pictographs and emoticons
+	{0x1f900, 0x1f9ff}, // Supplemental symbols and pictographs
+	{0x20000, 0x3fffd}, // CJK Unified Ideographs Extension B and later
+}
+
+// runeWidth returns the number of display columns of r: 0 for combining marks, 2 for wide characters.
+func runeWidth(r rune) int {
+	if unicode.In(r, unicode.Mn, unicode.Me) || r == 0x200d {
+		return 0
+	}
+	for _, wide := range wideRanges {
+		if r >= wide[0] && r <= wide[1] {
+			return 2
+		}
+	}
+	return 1
+}
@@ -0,0 +1,114 @@
+package parsercombinator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestFormatError(t *testing.T) {
+	lexer, err := NewLexer[int](
+		SkipRule(`\s+`),
+		RegexpRule("ident", `[a-z\p{Han}]+`),
+		RegexpRule("punct", `[()\[\],=]`),
+	)
+	assert.NoError(t, err)
+	call := Seq(TokenType[int]("ident"), Between(Literal[int]("("), Literal[int](")"), SepBy("args", TokenType[int]("ident"), Literal[int](","), TrailingForbid)))
+
+	tests := []struct {
+		name string
+		src  string
+		opts ErrorFormatOptions
+		want string
+	}{
+		{
+			name: "secondary label",
+			src:  "let x =\n  print(a b)\n",
+			opts: ErrorFormatOptions{Path: "main.txt"},
+			want: `error: not match expected: ')', actual: 'b' (to close '(' opened at 2:8)
+ --> main.txt:2:11
+  |
+2 |   print(a b)
+  |        - '(' opened here
+  |           ^
+`,
+		},
+		{
+			name: "underline spans the length",
+			src:  "print(a, bb cc)",
+			want: `error: not match expected: ')', actual: 'cc' (to close '(' opened at 1:6)
+ --> 1:13
+  |
+1 | print(a, bb cc)
+  |      - '(' opened here
+  |             ^^
+`,
+		},
+		{
+			name: "wide characters before the error",
+			src:  "名前 = print(a b)",
+			want: `error: not match expected: ')', actual: 'b' (to close '(' opened at 1:11)
+ --> 1:14
+  |
+1 | 名前 = print(a b)
+  |             - '(' opened here
+  |                ^
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := lexer.Tokenize(tt.src)
+			assert.NoError(t, err)
+			pc := NewParseContext[int]()
+			_, err = Evaluate(pc, tokens[firstIdent(tokens, "print"):], call)
+			assert.Error(t, err)
+			assert.Equal(t, tt.want, FormatError(tt.src, err, tt.opts))
+		})
+	}
+}
+
+func firstIdent(tokens []Token[int], raw string) int {
+	for i, token := range tokens {
+		if token.Raw == raw {
+			return i
+		}
+	}
+	return 0
+}
+
+func TestFormatErrorWithFiles(t *testing.T) {
+	lexer, err := NewLexer[int](SkipRule(`\s+`), RegexpRule("word", `[a-z]+`))
+	assert.NoError(t, err)
+	sources := NewSourceSet()
+	file := sources.Add("lib/words.txt", "alpha\n\tbravo charlie\n")
+	tokens, err := lexer.TokenizeSource(file)
+	assert.NoError(t, err)
+
+	pc := NewParseContext[int]()
+	_, err = Evaluate(pc, tokens, SeqWithLabel("sentence", Literal[int]("alpha"), Literal[int]("bravo"), Literal[int]("delta")))
+	assert.Equal(t, `error: not match expected: 'delta', actual: 'charlie'
+ --> lib/words.txt:2:8
+  |
+2 | 	bravo charlie
+  | 	      ^^^^^^^
+  = note: while parsing sentence
+`, FormatError("", err, ErrorFormatOptions{}))
+
+	colored := FormatError("", err, ErrorFormatOptions{Color: true})
+	assert.Contains(t, colored, "\x1b[1;31merror:\x1b[0m")
+	assert.Contains(t, colored, "\x1b[1;31m^^^^^^^\x1b[0m")
+}
+
+func TestFormatErrorWithoutSnippet(t *testing.T) {
+	// index-only positions and plain errors are printed as messages
+	pc := NewParseContext[string]()
+	_, err := EvaluateWithRawTokens(pc, []string{"a"}, rawLiteral("b"))
+	joined := errors.Join(err, errors.New("custom failure"))
+	assert.Equal(t, `error: not match expected: b, actual: a
+ --> 0
+
+error: custom failure
+`, FormatError("", joined, ErrorFormatOptions{}))
+}
@@ -0,0 +1,124 @@
+package parsercombinator
+
+import (
+	"slices"
+)
+
+// LayoutOptions configures Layout.
+//
+// Empty fields fall back to their defaults: NewlineType "newline", IndentType "indent",
+// DedentType "dedent", OpenBrackets "(", "[", "{" and CloseBrackets ")", "]", "}".
+type LayoutOptions struct {
+	NewlineType   string   // Type of newline tokens in the input
+	IndentType    string   // Type of the synthetic INDENT tokens
+	DedentType    string   // Type of the synthetic DEDENT tokens
+	OpenBrackets  []string // Raw text of tokens that suspend layout
+	CloseBrackets []string // Raw text of tokens that resume layout
+}
+
+func (o LayoutOptions) withDefaults() LayoutOptions {
+	if o.NewlineType == "" {
+		o.NewlineType = "newline"
+	}
+	if o.IndentType == "" {
+		o.IndentType = "indent"
+	}
+	if o.DedentType == "" {
+		o.DedentType = "dedent"
+	}
+	if len(o.OpenBrackets) == 0 {
+		o.OpenBrackets = []string{"(", "[", "{"}
+	}
+	if len(o.CloseBrackets) == 0 {
+		o.CloseBrackets = []string{")", "]", "}"}
+	}
+	return o
+}
+
+// Layout converts an indentation-sensitive token stream into one that Seq and Repeat can parse.
+//
+// The input must contain newline tokens (LayoutOptions.NewlineType) and tokens with Pos.Col set,
+// as produced by Lexer. Tokens without a column are treated as column 1. The first token of each line is compared with the current indentation:
+// a deeper column emits an indent token, a shallower column emits one dedent token per closed block.
+// Every non-blank line ends with exactly one newline token; blank lines are dropped.
+// Inside brackets layout is suspended, so newlines there are dropped and continued lines may
+// have any indentation. Remaining blocks are closed at the end of input.
+//
+// A dedent to a column that doesn't match any enclosing block is reported as a *ParseError.
+func Layout[T any](tokens []Token[T], options LayoutOptions) ([]Token[T], error) {
+	o := options.withDefaults()
+	result := make([]Token[T], 0, len(tokens))
+	indents := []int{1}
+	depth := 0
+	lineStart := true
+
+	for _, t := range tokens {
+		if t.Type == o.NewlineType {
+			if depth == 0 && !lineStart {
+				result = append(result, t)
+				lineStart = true
+			}
+			continue
+		}
+		if lineStart && depth == 0 {
+			col := 1
+			if t.Pos != nil && t.Pos.Col > 0 {
+				col = t.Pos.Col
+			}
+			if col > indents[len(indents)-1] {
+				indents = append(indents, col)
+				result = append(result, Token[T]{Type: o.IndentType, Pos: emptyPos(t.Pos)})
+			}
+			for col < indents[len(indents)-1] {
+				indents = indents[:len(indents)-1]
+				result = append(result, Token[T]{Type: o.DedentType, Pos: emptyPos(t.Pos)})
+			}
+			if col != indents[len(indents)-1] {
+				return nil, NewErrCritical("inconsistent dedent: indentation doesn't match any outer block", t.Pos)
+			}
+		}
+		lineStart = false
+		if slices.Contains(o.OpenBrackets, t.Raw) {
+			depth++
+		} else if slices.Contains(o.CloseBrackets, t.Raw) && depth > 0 {
+			depth--
+		}
+		result = append(result, t)
+	}
+
+	var end *Pos
+	if len(tokens) > 0 {
+		end = endPos(tokens[len(tokens)-1])
+	}
+	if !lineStart {
+		result = append(result, Token[T]{Type: o.NewlineType, Pos: end})
+	}
+	for range indents[1:] {
+		result = append(result, Token[T]{Type: o.DedentType, Pos: end})
+	}
+	return result, nil
+}
+
+// emptyPos returns a zero-length position at the start of pos.
+func emptyPos(pos *Pos) *Pos {
+	if pos == nil {
+		return nil
+	}
+	p := pos.Copy()
+	p.Length = 0
+	return p
+}
+
+// endPos returns a zero-length position just after the token. For index-only positions
+// (EvaluateWithRawTokens), it is the index of the next token.
+func endPos[T any](t Token[T]) *Pos {
+	if t.Pos == nil {
+		return nil
+	}
+	if t.Pos.Line == 0 && t.Pos.Col == 0 {
+		return &Pos{Index: t.Pos.Index + 1, File: t.Pos.File}
+	}
+	c := cursor{line: t.Pos.Line, col: t.Pos.Col, index: t.Pos.Index, file: t.Pos.File}
+	c.advance(t.Raw)
+	return c.pos(0)
+}
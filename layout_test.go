@@ -0,0 +1,122 @@
+package parsercombinator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func layoutTokens(t *testing.T, src string) ([]Token[int], error) {
+	lexer, err := NewLexer[int](
+		SkipRule(`[ \t]+`),
+		SkipRule(`#[^\n]*`),
+		RegexpRule("newline", `\n`),
+		RegexpRule("ident", `[a-z]+`),
+		RegexpRule("punct", `[:()\[\],]`),
+	)
+	assert.NoError(t, err)
+	tokens, err := lexer.Tokenize(src)
+	assert.NoError(t, err)
+	return Layout(tokens, LayoutOptions{})
+}
+
+func layoutTypes(tokens []Token[int]) string {
+	var types []string
+	for _, t := range tokens {
+		switch t.Type {
+		case "ident", "punct":
+			types = append(types, t.Raw)
+		default:
+			types = append(types, strings.ToUpper(t.Type))
+		}
+	}
+	return strings.Join(types, " ")
+}
+
+func TestLayout(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "flat",
+			src:  "a\nb\n",
+			want: "a NEWLINE b NEWLINE",
+		},
+		{
+			name: "nested blocks closed at end of input",
+			src:  "if:\n  a\n  if:\n    b",
+			want: "if : NEWLINE INDENT a NEWLINE if : NEWLINE INDENT b NEWLINE DEDENT DEDENT",
+		},
+		{
+			name: "multiple dedents",
+			src:  "if:\n  if:\n    a\nb\n",
+			want: "if : NEWLINE INDENT if : NEWLINE INDENT a NEWLINE DEDENT DEDENT b NEWLINE",
+		},
+		{
+			name: "blank and comment lines are ignored",
+			src:  "if:\n\n  a\n      # comment\n  b\n",
+			want: "if : NEWLINE INDENT a NEWLINE b NEWLINE DEDENT",
+		},
+		{
+			name: "bracket continuation",
+			src:  "f(a,\n      b,\n c)\nd\n",
+			want: "f ( a , b , c ) NEWLINE d NEWLINE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := layoutTokens(t, tt.src)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, layoutTypes(tokens))
+		})
+	}
+}
+
+func TestLayoutInconsistentDedent(t *testing.T) {
+	_, err := layoutTokens(t, "if:\n    a\n  b\n")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrCritical))
+	var pe *ParseError
+	assert.True(t, errors.As(err, &pe))
+	assert.Equal(t, "3:3", pe.Pos.String())
+}
+
+func TestLayoutWithParser(t *testing.T) {
+	tokens, err := layoutTokens(t, "if:\n  a\n  b\nc\n")
+	assert.NoError(t, err)
+
+	statement := Seq(TokenType[int]("ident"), Drop(TokenType[int]("newline")))
+	block := Seq(
+		Drop(Seq(TokenType[int]("ident"), TokenType[int]("punct"), TokenType[int]("newline"), TokenType[int]("indent"))),
+		OneOrMore("block", statement),
+		Drop(TokenType[int]("dedent")),
+	)
+	pc := NewParseContext[int]()
+	_, err = Evaluate(pc, tokens, Seq(block, statement, EOS[int]()))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(pc.Results))
+}
+
+func TestLayoutEndPositions(t *testing.T) {
+	// tokens from Lexer: the closing tokens are placed after the last character
+	tokens, err := layoutTokens(t, "if:\n  ab")
+	assert.NoError(t, err)
+	assert.Equal(t, "if : NEWLINE INDENT ab NEWLINE DEDENT", layoutTypes(tokens))
+	for _, closing := range tokens[len(tokens)-2:] {
+		assert.Equal(t, Pos{Line: 2, Col: 5, Index: 8}, *closing.Pos)
+	}
+
+	// index-only positions (like EvaluateWithRawTokens): the closing tokens are placed at the next index
+	var raw []Token[int]
+	for i, typ := range []string{"ident", "newline", "ident"} {
+		raw = append(raw, Token[int]{Type: typ, Raw: typ, Pos: &Pos{Index: i}})
+	}
+	tokens, err = Layout(raw, LayoutOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "ident NEWLINE ident NEWLINE", layoutTypes(tokens))
+	assert.Equal(t, "3", tokens[len(tokens)-1].Pos.String())
+}
@@ -0,0 +1,76 @@
+package parsercombinator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// errLeftRecursionSeed is the initial seed of a rule being grown. It lets the non-recursive
+// alternatives match, but isn't a failure of the input, so farthest failures ignore it.
+var errLeftRecursionSeed = fmt.Errorf("%w: left recursion", ErrNotMatch)
+
+// positionKey identifies a rule applied at a position of the token stream.
+// Parsers receive suffixes of the input, so the address of the first token identifies the position.
+type positionKey[T any] struct {
+	rule   any
+	head   *Token[T]
+	length int
+}
+
+func newPositionKey[T any](rule any, src []Token[T]) positionKey[T] {
+	key := positionKey[T]{rule: rule, length: len(src)}
+	if len(src) > 0 {
+		key.head = &src[0]
+	}
+	return key
+}
+
+// leftRecursion holds the best result of a rule that is being grown at one position
+type leftRecursion[T any] struct {
+	consumed int
+	tokens   []Token[T]
+	err      error
+	hit      bool // The rule called itself at the same position
+}
+
+// growLeftRecursion applies a rule with the seed-growing algorithm (Warth et al.).
+//
+// A recursive call of the rule at the same position returns the current seed instead of
+// recursing: first a failure, so the non-recursive alternatives match, then the longest
+// result so far. The body is re-evaluated while the result keeps getting longer. Rules
+// that don't call themselves at the same position are evaluated only once.
+//
+// Seeds exist only while the rule is being grown, so rules involved in indirect recursion
+// are re-evaluated with the latest seed in every iteration.
+func growLeftRecursion[T any](pctx *ParseContext[T], rule any, body Parser[T], src []Token[T]) (int, []Token[T], error) {
+	key := newPositionKey(rule, src)
+	if seed, ok := pctx.growing[key]; ok {
+		seed.hit = true
+		pctx.seedReads++
+		return seed.consumed, seed.tokens, seed.err
+	}
+	if pctx.growing == nil {
+		pctx.growing = make(map[positionKey[T]]*leftRecursion[T])
+	}
+	seed := &leftRecursion[T]{err: &ParseError{Parent: errLeftRecursionSeed, Pos: getFirstPos(src)}}
+	pctx.growing[key] = seed
+	defer delete(pctx.growing, key)
+
+	for {
+		consumed, newTokens, err := body(pctx, src)
+		if !seed.hit {
+			return consumed, newTokens, err
+		}
+		if err != nil {
+			if seed.err != nil || !(errors.Is(err, ErrNotMatch) || errors.Is(err, ErrRepeatCount)) {
+				return consumed, newTokens, err
+			}
+			break
+		}
+		if seed.err == nil && consumed <= seed.consumed {
+			break
+		}
+		seed.consumed, seed.tokens, seed.err = consumed, newTokens, nil
+	}
+	return seed.consumed, seed.tokens, seed.err
+}
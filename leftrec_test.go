@@ -0,0 +1,144 @@
+package parsercombinator
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func leftRecNumber() Parser[int] {
+	return Trans(RawRegexp[int](digitsPattern), func(pc *ParseContext[int], src []Token[int]) ([]Token[int], error) {
+		v, err := strconv.Atoi(src[0].Raw)
+		return []Token[int]{{Type: "number", Pos: src[0].Pos, Val: v}}, err
+	})
+}
+
+func leftRecApply(pc *ParseContext[int], src []Token[int]) ([]Token[int], error) {
+	result := src[0].Val - src[2].Val
+	if src[1].Raw == "+" {
+		result = src[0].Val + src[2].Val
+	}
+	return []Token[int]{{Type: "number", Pos: src[0].Pos, Val: result}}, nil
+}
+
+func TestLeftRecursionAlias(t *testing.T) {
+	defineExpr, expr := NewAlias[int]("expr")
+	parser := defineExpr(Or(
+		Trans(Seq(expr, OneOfLiterals[int]("+", "-"), leftRecNumber()), leftRecApply),
+		leftRecNumber(),
+	))
+
+	tests := []struct {
+		src  string
+		want int
+	}{
+		{src: "7", want: 7},
+		{src: "10 - 3", want: 7},
+		{src: "10 - 3 - 2", want: 5},
+		{src: "10 - 3 + 2 - 1", want: 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			pc := NewParseContext[int]()
+			pc.LeftRecursion = true
+			result, err := EvaluateWithRawTokens(pc, strings.Fields(tt.src), Seq(parser, EOS[int]()))
+			assert.NoError(t, err)
+			assert.Equal(t, []int{tt.want}, result)
+			assert.Zero(t, pc.growing)
+		})
+	}
+}
+
+func TestLeftRecursionLazy(t *testing.T) {
+	var expr Parser[int]
+	expr = Lazy(func() Parser[int] {
+		return Or(
+			Trans(Seq(expr, Literal[int]("-"), leftRecNumber()), leftRecApply),
+			leftRecNumber(),
+		)
+	})
+	pc := NewParseContext[int]()
+	pc.LeftRecursion = true
+	result, err := EvaluateWithRawTokens(pc, strings.Fields("20 - 5 - 4 - 1"), Seq(expr, EOS[int]()))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{10}, result)
+}
+
+func TestLeftRecursionIndirect(t *testing.T) {
+	// expr := difference | number
+	// difference := expr "-" number
+	defineExpr, expr := NewAlias[int]("expr")
+	defineDifference, difference := NewAlias[int]("difference")
+	defineDifference(Trans(Seq(expr, Literal[int]("-"), leftRecNumber()), leftRecApply))
+	parser := defineExpr(Or(difference, leftRecNumber()))
+
+	pc := NewParseContext[int]()
+	pc.LeftRecursion = true
+	result, err := EvaluateWithRawTokens(pc, strings.Fields("20 - 5 - 4 - 1"), Seq(parser, EOS[int]()))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{10}, result)
+}
+
+func TestLeftRecursionMultipleLevels(t *testing.T) {
+	// sum := sum "+" product | product
+	// product := product "*" number | number
+	defineSum, sum := NewAlias[int]("sum")
+	defineProduct, product := NewAlias[int]("product")
+	defineProduct(Or(
+		Trans(Seq(product, Literal[int]("*"), leftRecNumber()), func(pc *ParseContext[int], src []Token[int]) ([]Token[int], error) {
+			return []Token[int]{{Type: "number", Pos: src[0].Pos, Val: src[0].Val * src[2].Val}}, nil
+		}),
+		leftRecNumber(),
+	))
+	parser := defineSum(Or(
+		Trans(Seq(sum, Literal[int]("+"), product), leftRecApply),
+		product,
+	))
+
+	pc := NewParseContext[int]()
+	pc.LeftRecursion = true
+	result, err := EvaluateWithRawTokens(pc, strings.Fields("1 + 2 * 3 * 4 + 5"), Seq(parser, EOS[int]()))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{30}, result)
+
+	_, err = EvaluateWithRawTokens(pc, strings.Fields("1 + * 3"), Seq(parser, EOS[int]()))
+	assert.Error(t, err)
+}
+
+func TestLeftRecursionDisabled(t *testing.T) {
+	defineExpr, expr := NewAlias[int]("expr")
+	parser := defineExpr(Or(
+		Trans(Seq(expr, Literal[int]("-"), leftRecNumber()), leftRecApply),
+		leftRecNumber(),
+	))
+	pc := NewParseContext[int]()
+	pc.MaxDepth = 50
+	_, err := EvaluateWithRawTokens(pc, strings.Fields("10 - 3"), parser)
+	assert.True(t, errors.Is(err, ErrStackOverflow))
+}
+
+func TestLeftRecursionErrorMessage(t *testing.T) {
+	defineExpr, expr := NewAlias[int]("expr")
+	alias := defineExpr(Or(Seq(expr, Literal[int]("+"), Literal[int]("1")), Literal[int]("1")))
+	var lazy Parser[int]
+	lazy = Lazy(func() Parser[int] {
+		return Or(Seq(lazy, Literal[int]("+"), Literal[int]("1")), Literal[int]("1"))
+	})
+
+	for name, parser := range map[string]Parser[int]{"alias": alias, "lazy": lazy} {
+		t.Run(name, func(t *testing.T) {
+			pc := NewParseContext[int]()
+			pc.LeftRecursion = true
+			_, err := EvaluateWithRawTokens(pc, []string{"*"}, parser)
+			assert.Error(t, err)
+			assert.NotContains(t, err.Error(), "left recursion")
+			assert.Contains(t, err.Error(), "not match expected: '1', actual: '*' at 0")
+
+			_, err = EvaluateWithRawTokens(pc, []string{"1", "+", "*"}, Seq(parser, EOS[int]()))
+			assert.Contains(t, err.Error(), "not match expected: '1', actual: '*' at 2")
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package parsercombinator
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// LexRule describes one token kind recognized by Lexer.
+//
+// A rule matches either a regular expression (Pattern) or a fixed string (Literal).
+// Rules whose Skip flag is set consume input (whitespace, comments) without producing tokens.
+// PushMode and PopMode switch the rule set of a Lexer created by NewLexerWithModes after the rule matches.
+type LexRule struct {
+	Type     string
+	Pattern  string
+	Literal  string
+	Skip     bool
+	PushMode string // Mode to enter after this rule matches
+	PopMode  bool   // Return to the previous mode after this rule matches (applied before PushMode)
+
+	re *regexp.Regexp
+}
+
+// RegexpRule creates a rule that produces tokens of tokenType for text matching pattern.
+func RegexpRule(tokenType, pattern string) LexRule {
+	return LexRule{Type: tokenType, Pattern: pattern}
+}
+
+// LiteralRule creates a rule that produces tokens of tokenType for the exact literal text.
+func LiteralRule(tokenType, literal string) LexRule {
+	return LexRule{Type: tokenType, Literal: literal}
+}
+
+// SkipRule creates a rule that discards text matching pattern (whitespace, comments, etc.).
+func SkipRule(pattern string) LexRule {
+	return LexRule{Type: "skip", Pattern: pattern, Skip: true}
+}
+
+// Push returns a copy of the rule that enters mode after matching.
+func (r LexRule) Push(mode string) LexRule {
+	r.PushMode = mode
+	return r
+}
+
+// Pop returns a copy of the rule that returns to the previous mode after matching.
+func (r LexRule) Pop() LexRule {
+	r.PopMode = true
+	return r
+}
+
+// match returns the length in bytes of the rule's match at the head of src, or -1.
+func (r *LexRule) match(src string) int {
+	if r.re == nil {
+		if r.Literal != "" && strings.HasPrefix(src, r.Literal) {
+			return len(r.Literal)
+		}
+		return -1
+	}
+	loc := r.re.FindStringIndex(src)
+	if loc == nil {
+		return -1
+	}
+	return loc[1]
+}
+
+// DefaultLexMode is the name of the mode a Lexer starts in.
+const DefaultLexMode = "default"
+
+// Lexer converts source text into []Token[T] with fully populated positions.
+//
+// At every offset all rules of the current mode are tried. The longest match wins and ties
+// are resolved by rule priority (the rule declared first). Empty matches are ignored.
+type Lexer[T any] struct {
+	modes map[string][]LexRule
+}
+
+// NewLexer compiles the rules and creates a Lexer. It fails if any pattern is not a valid regexp.
+func NewLexer[T any](rules ...LexRule) (*Lexer[T], error) {
+	return NewLexerWithModes[T](map[string][]LexRule{DefaultLexMode: rules})
+}
+
+// NewLexerWithModes creates a Lexer with several rule sets.
+//
+// Lexing starts in DefaultLexMode. Rules with Push/Pop maintain a stack of modes, so that
+// one token stream can contain e.g. template text and embedded expressions:
+//
+//	lexer, err := NewLexerWithModes[T](map[string][]LexRule{
+//		DefaultLexMode: {LiteralRule("string-start", `"`).Push("string"), LiteralRule("rbrace", "}").Pop(), ...},
+//		"string":       {RegexpRule("text", `[^"$]+`), LiteralRule("interp-start", "${").Push(DefaultLexMode), LiteralRule("string-end", `"`).Pop()},
+//	})
+func NewLexerWithModes[T any](modes map[string][]LexRule) (*Lexer[T], error) {
+	if _, ok := modes[DefaultLexMode]; !ok {
+		return nil, fmt.Errorf("lexer mode %s is required", DefaultLexMode)
+	}
+	compiledModes := make(map[string][]LexRule, len(modes))
+	for mode, rules := range modes {
+		compiled := make([]LexRule, len(rules))
+		for i, r := range rules {
+			if r.Pattern != "" {
+				re, err := regexp.Compile(`\A(?:` + r.Pattern + `)`)
+				if err != nil {
+					return nil, fmt.Errorf("lexer rule %s/%d (%s): %w", mode, i, r.Type, err)
+				}
+				r.re = re
+			} else if r.Literal == "" {
+				return nil, fmt.Errorf("lexer rule %s/%d (%s): pattern or literal is required", mode, i, r.Type)
+			}
+			if _, ok := modes[r.PushMode]; r.PushMode != "" && !ok {
+				return nil, fmt.Errorf("lexer rule %s/%d (%s): unknown mode %s", mode, i, r.Type, r.PushMode)
+			}
+			compiled[i] = r
+		}
+		compiledModes[mode] = compiled
+	}
+	return &Lexer[T]{modes: compiledModes}, nil
+}
+
+// Tokenize splits src into tokens.
+//
+// Pos.Index and Pos.Length are byte offsets into src, Pos.Line and Pos.Col are 1-based
+// and Col counts runes. Input that no rule matches, popping the last mode, and input that
+// ends inside a pushed mode are reported as a *ParseError.
+func (l *Lexer[T]) Tokenize(src string) ([]Token[T], error) {
+	return l.tokenize(src, nil, false)
+}
+
+// TokenizeSource works like Tokenize and sets Pos.File of every token to source.
+func (l *Lexer[T]) TokenizeSource(source *Source) ([]Token[T], error) {
+	return l.tokenize(source.Text, source, false)
+}
+
+// TokenizeLossless works like Tokenize but keeps the text of Skip rules as trivia.
+//
+// Skipped text on the same line after a token becomes its Trailing trivia; everything
+// from the first trivia containing a newline goes to the Leading trivia of the next token.
+// Trivia at the end of src is attached to the last token (or to a single token of Type "eof"
+// when src has no tokens, which EOS accepts), so SourceText reproduces src byte for byte.
+func (l *Lexer[T]) TokenizeLossless(src string) ([]Token[T], error) {
+	return l.tokenize(src, nil, true)
+}
+
+// TokenizeSourceLossless works like TokenizeLossless and sets Pos.File of every token to source.
+func (l *Lexer[T]) TokenizeSourceLossless(source *Source) ([]Token[T], error) {
+	return l.tokenize(source.Text, source, true)
+}
+
+func (l *Lexer[T]) tokenize(src string, file *Source, lossless bool) ([]Token[T], error) {
+	var result []Token[T]
+	var trivia []Trivia
+	trailing := false
+	c := cursor{line: 1, col: 1, file: file}
+	modeStack := []string{DefaultLexMode}
+	for c.index < len(src) {
+		rest := src[c.index:]
+		rules := l.modes[modeStack[len(modeStack)-1]]
+		best := -1
+		bestLength := 0
+		for i := range rules {
+			if length := rules[i].match(rest); length > bestLength {
+				best = i
+				bestLength = length
+			}
+		}
+		if best == -1 {
+			r, size := utf8.DecodeRuneInString(rest)
+			actual := strconv.QuoteRune(r)
+			if r == utf8.RuneError && size == 1 {
+				actual = fmt.Sprintf(`'\x%02x'`, rest[0]) // invalid UTF-8
+			}
+			return nil, NewErrNotMatch("token", actual, c.pos(size))
+		}
+		raw := rest[:bestLength]
+		rule := &rules[best]
+		if !rule.Skip {
+			result = append(result, Token[T]{Type: rule.Type, Pos: c.pos(bestLength), Raw: raw, Leading: trivia})
+			trivia = nil
+			trailing = true
+		} else if lossless {
+			piece := Trivia{Type: rule.Type, Raw: raw, Pos: c.pos(bestLength)}
+			if trailing && !strings.Contains(raw, "\n") {
+				last := &result[len(result)-1]
+				last.Trailing = append(last.Trailing, piece)
+			} else {
+				trivia = append(trivia, piece)
+				trailing = false
+			}
+		}
+		if rule.PopMode {
+			if len(modeStack) == 1 {
+				return nil, NewErrCritical(fmt.Sprintf("unbalanced %s: no lexer mode to return to", strconv.Quote(raw)), c.pos(bestLength))
+			}
+			modeStack = modeStack[:len(modeStack)-1]
+		}
+		if rule.PushMode != "" {
+			modeStack = append(modeStack, rule.PushMode)
+		}
+		c.advance(raw)
+	}
+	if len(modeStack) > 1 {
+		return nil, NewErrCritical(fmt.Sprintf("unexpected end of input in lexer mode %s", modeStack[len(modeStack)-1]), c.pos(0))
+	}
+	if len(trivia) > 0 {
+		if len(result) == 0 {
+			result = append(result, Token[T]{Type: "eof", Pos: c.pos(0), Leading: trivia, triviaHolder: true})
+		} else {
+			last := &result[len(result)-1]
+			last.Trailing = append(last.Trailing, trivia...)
+		}
+	}
+	return result, nil
+}
+
+// EvaluateWithLexer tokenizes src with the lexer and evaluates the parser over the resulting tokens.
+func EvaluateWithLexer[T any](pc *ParseContext[T], lexer *Lexer[T], src string, parser Parser[T]) (result []T, err error) {
+	tokens, err := lexer.Tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	return Evaluate(pc, tokens, parser)
+}
+
+// cursor tracks line and column while walking through source text.
+type cursor struct {
+	line  int
+	col   int
+	index int
+	file  *Source
+}
+
+func (c *cursor) pos(length int) *Pos {
+	return &Pos{Line: c.line, Col: c.col, Index: c.index, Length: length, File: c.file}
+}
+
+func (c *cursor) advance(text string) {
+	for _, r := range text {
+		if r == '\n' {
+			c.line++
+			c.col = 1
+		} else {
+			c.col++
+		}
+	}
+	c.index += len(text)
+}
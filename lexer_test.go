@@ -0,0 +1,184 @@
+package parsercombinator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func newTestLexer(t *testing.T) *Lexer[int] {
+	lexer, err := NewLexer[int](
+		SkipRule(`[ \t\r\n]+`),
+		SkipRule(`//[^\n]*`),
+		LiteralRule("keyword", "if"),
+		RegexpRule("ident", `[a-zA-Z_][a-zA-Z0-9_]*`),
+		RegexpRule("number", `[0-9]+`),
+		LiteralRule("op", "="),
+		LiteralRule("op", "=="),
+		LiteralRule("op", "+"),
+	)
+	assert.NoError(t, err)
+	return lexer
+}
+
+func TestLexerTokenize(t *testing.T) {
+	lexer := newTestLexer(t)
+	tokens, err := lexer.Tokenize("if x == 10 // comment\n  ifdef = 2")
+	assert.NoError(t, err)
+
+	type tok struct {
+		Type string
+		Raw  string
+		Pos  Pos
+	}
+	var got []tok
+	for _, token := range tokens {
+		got = append(got, tok{token.Type, token.Raw, *token.Pos})
+	}
+	assert.Equal(t, []tok{
+		{"keyword", "if", Pos{Line: 1, Col: 1, Index: 0, Length: 2}},
+		{"ident", "x", Pos{Line: 1, Col: 4, Index: 3, Length: 1}},
+		{"op", "==", Pos{Line: 1, Col: 6, Index: 5, Length: 2}},
+		{"number", "10", Pos{Line: 1, Col: 9, Index: 8, Length: 2}},
+		{"ident", "ifdef", Pos{Line: 2, Col: 3, Index: 24, Length: 5}},
+		{"op", "=", Pos{Line: 2, Col: 9, Index: 30, Length: 1}},
+		{"number", "2", Pos{Line: 2, Col: 11, Index: 32, Length: 1}},
+	}, got)
+}
+
+func TestLexerMultibyteColumn(t *testing.T) {
+	lexer, err := NewLexer[int](
+		SkipRule(` +`),
+		RegexpRule("word", `\pL+`),
+	)
+	assert.NoError(t, err)
+	tokens, err := lexer.Tokenize("日本 語")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(tokens))
+	assert.Equal(t, Pos{Line: 1, Col: 4, Index: 7, Length: 3}, *tokens[1].Pos)
+}
+
+func TestLexerError(t *testing.T) {
+	lexer := newTestLexer(t)
+	_, err := lexer.Tokenize("x = 1\ny = $")
+	assert.Error(t, err)
+	var pe *ParseError
+	assert.True(t, errors.As(err, &pe))
+	assert.True(t, errors.Is(err, ErrNotMatch))
+	assert.Equal(t, "2:5", pe.Pos.String())
+	assert.Equal(t, 10, pe.Pos.Index)
+}
+
+func TestLexerInvalidUTF8(t *testing.T) {
+	lexer := newTestLexer(t)
+	_, err := lexer.Tokenize("x = \xff")
+	var pe *ParseError
+	assert.True(t, errors.As(err, &pe))
+	assert.Equal(t, Pos{Line: 1, Col: 5, Index: 4, Length: 1}, *pe.Pos)
+	assert.EqualError(t, err, `not match expected: token, actual: '\xff' at 1:5`)
+
+	_, err = lexer.Tokenize("x = あ")
+	assert.True(t, errors.As(err, &pe))
+	assert.Equal(t, 3, pe.Pos.Length)
+}
+
+func TestLexerInvalidRule(t *testing.T) {
+	_, err := NewLexer[int](RegexpRule("bad", `[`))
+	assert.Error(t, err)
+	_, err = NewLexer[int](LexRule{Type: "empty"})
+	assert.Error(t, err)
+}
+
+func TestEvaluateWithLexer(t *testing.T) {
+	lexer := newTestLexer(t)
+	number := func(pc *ParseContext[int], src []Token[int]) (int, []Token[int], error) {
+		if len(src) == 0 || src[0].Type != "number" {
+			return 0, nil, NewErrNotMatch("number", "", getFirstPos(src))
+		}
+		return 1, src[:1], nil
+	}
+	pc := NewParseContext[int]()
+	_, err := EvaluateWithLexer(pc, lexer, "1 2 3", ZeroOrMore("numbers", number))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(pc.Results))
+	assert.Equal(t, "1:5", pc.Results[2].Pos.String())
+}
+
+func newTemplateLexer(t *testing.T) *Lexer[int] {
+	lexer, err := NewLexerWithModes[int](map[string][]LexRule{
+		DefaultLexMode: {
+			SkipRule(`[ \t\n]+`),
+			RegexpRule("ident", `[a-zA-Z_][a-zA-Z0-9_]*`),
+			LiteralRule("dot", "."),
+			LiteralRule("lbrace", "{").Push(DefaultLexMode),
+			LiteralRule("rbrace", "}").Pop(),
+			LiteralRule("string-start", `"`).Push("string"),
+		},
+		"string": {
+			RegexpRule("text", `([^"$\\]|\\.|\$[^{])+`),
+			LiteralRule("interp-start", "${").Push(DefaultLexMode),
+			LiteralRule("string-end", `"`).Pop(),
+		},
+	})
+	assert.NoError(t, err)
+	return lexer
+}
+
+func TestLexerModes(t *testing.T) {
+	lexer := newTemplateLexer(t)
+	tokens, err := lexer.Tokenize(`x "hello ${user.name}!" y`)
+	assert.NoError(t, err)
+
+	var got []string
+	for _, token := range tokens {
+		got = append(got, token.Type+":"+token.Raw+"@"+token.Pos.String())
+	}
+	assert.Equal(t, []string{
+		"ident:x@1:1",
+		`string-start:"@1:3`,
+		"text:hello @1:4",
+		"interp-start:${@1:10",
+		"ident:user@1:12",
+		"dot:.@1:16",
+		"ident:name@1:17",
+		"rbrace:}@1:21",
+		"text:!@1:22",
+		`string-end:"@1:23`,
+		"ident:y@1:25",
+	}, got)
+}
+
+func TestLexerModesNested(t *testing.T) {
+	lexer := newTemplateLexer(t)
+	tokens, err := lexer.Tokenize(`"a${ {b} "c${d}" }"`)
+	assert.NoError(t, err)
+	var types []string
+	for _, token := range tokens {
+		types = append(types, token.Type)
+	}
+	assert.Equal(t, []string{
+		"string-start", "text", "interp-start", "lbrace", "ident", "rbrace",
+		"string-start", "text", "interp-start", "ident", "rbrace", "string-end",
+		"rbrace", "string-end",
+	}, types)
+}
+
+func TestLexerModesError(t *testing.T) {
+	lexer := newTemplateLexer(t)
+
+	_, err := lexer.Tokenize(`"hello ${name`)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrCritical))
+	assert.Contains(t, err.Error(), "1:14")
+
+	_, err = lexer.Tokenize("a }")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrCritical))
+	assert.Contains(t, err.Error(), "1:3")
+
+	_, err = NewLexerWithModes[int](map[string][]LexRule{
+		DefaultLexMode: {LiteralRule("quote", `"`).Push("missing")},
+	})
+	assert.Error(t, err)
+}
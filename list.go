@@ -0,0 +1,152 @@
+package parsercombinator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TrailingSeparator defines whether a separated list may end with a separator
+type TrailingSeparator int
+
+const (
+	// TrailingForbid - a separator after the last element is an error
+	TrailingForbid TrailingSeparator = iota
+	// TrailingAllow - a separator after the last element is consumed if present
+	TrailingAllow
+	// TrailingRequire - every element must be followed by a separator
+	TrailingRequire
+)
+
+func (ts TrailingSeparator) String() string {
+	switch ts {
+	case TrailingForbid:
+		return "Forbid"
+	case TrailingAllow:
+		return "Allow"
+	case TrailingRequire:
+		return "Require"
+	default:
+		return "Unknown"
+	}
+}
+
+// SepBy matches zero or more elements separated by sep and returns only the element tokens.
+//
+// With TrailingForbid, a separator that isn't followed by an element is reported as
+// "trailing ',' not allowed" at the separator's position.
+func SepBy[T any](label string, elem, sep Parser[T], trailing TrailingSeparator) Parser[T] {
+	return sepBy(label, 0, elem, sep, trailing)
+}
+
+// SepBy1 works like SepBy but requires at least one element.
+func SepBy1[T any](label string, elem, sep Parser[T], trailing TrailingSeparator) Parser[T] {
+	return sepBy(label, 1, elem, sep, trailing)
+}
+
+// SepEndBy matches zero or more elements separated and optionally terminated by sep.
+func SepEndBy[T any](label string, elem, sep Parser[T]) Parser[T] {
+	return sepBy(label, 0, elem, sep, TrailingAllow)
+}
+
+// EndBy matches zero or more elements each terminated by sep (like statements ending with ';').
+func EndBy[T any](label string, elem, sep Parser[T]) Parser[T] {
+	return sepBy(label, 0, elem, sep, TrailingRequire)
+}
+
+func sepBy[T any](label string, min int, elem, sep Parser[T], trailing TrailingSeparator) Parser[T] {
+	return Trace(label, func(pctx *ParseContext[T], tokens []Token[T]) (int, []Token[T], error) {
+		converted := make([]Token[T], 0, len(tokens))
+		offset := 0
+		count := 0
+		var lastSep *Token[T] // separator not yet followed by an element
+		for offset < len(tokens) {
+			saved := pctx.snapshot()
+			consumed, newTokens, err := elem(pctx, tokens[offset:])
+			if errors.Is(err, ErrNotMatch) {
+				pctx.restore(saved)
+				if lastSep == nil || trailing != TrailingForbid {
+					pctx.noteFailure(err)
+				} else if !endsList(pctx, tokens[offset:]) {
+					// the element after the separator is broken; the separator is fine
+					return 0, []Token[T]{}, err
+				}
+				// otherwise the trailing separator error below explains the failure better
+				break
+			} else if err != nil {
+				return 0, []Token[T]{}, err
+			}
+			converted = append(converted, newTokens...)
+			offset += consumed
+			count++
+			lastSep = nil
+
+			if offset >= len(tokens) {
+				if trailing == TrailingRequire {
+					// let sep report what it expects at the end of input
+					if _, _, err := sep(pctx, tokens[offset:]); err != nil {
+						return 0, []Token[T]{}, locateAfter(err, tokens[offset-1])
+					}
+				}
+				break
+			}
+			saved = pctx.snapshot()
+			sepConsumed, _, err := sep(pctx, tokens[offset:])
+			if errors.Is(err, ErrNotMatch) && trailing != TrailingRequire {
+				pctx.noteFailure(err)
+				pctx.restore(saved)
+				break
+			} else if err != nil {
+				return 0, []Token[T]{}, err
+			}
+			if sepConsumed > 0 {
+				lastSep = &tokens[offset]
+			} else if consumed == 0 {
+				break
+			}
+			offset += sepConsumed
+		}
+		if lastSep != nil && trailing == TrailingForbid {
+			return 0, []Token[T]{}, newErrNotMatchMessage(fmt.Sprintf("trailing %s not allowed", describeToken(*lastSep)),
+				expectedAt(pctx, elem, tokens[offset:]), describeActual(tokens[offset:]), lastSep.Pos)
+		}
+		if count < min {
+			return 0, tokens, NewErrRepeatCount(label, min, count, getFirstPos(tokens))
+		}
+		return offset, converted, nil
+	})
+}
+
+// endsList reports whether a list can end right before src: at the end of input or at a token
+// that closes an enclosing Between.
+func endsList[T any](pctx *ParseContext[T], src []Token[T]) bool {
+	if len(src) == 0 {
+		return true
+	}
+	for _, opened := range pctx.delimiters {
+		saved, farthest := pctx.snapshot(), pctx.farthest.clone()
+		_, _, err := opened.close(pctx, src)
+		pctx.restore(saved)
+		pctx.farthest = farthest
+		if err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// locateAfter returns a copy of err located right after last when err has no position,
+// like the errors matchers report at the end of input.
+func locateAfter[T any](err error, last Token[T]) error {
+	pe, ok := err.(*ParseError)
+	if !ok || pe.Pos != nil {
+		return err
+	}
+	located := *pe
+	located.Pos = endPos(last)
+	if nm, ok := pe.Parent.(*NotMatchError); ok && nm.Pos == nil {
+		copied := *nm
+		copied.Pos = located.Pos
+		located.Parent = &copied
+	}
+	return &located
+}
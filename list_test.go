@@ -0,0 +1,89 @@
+package parsercombinator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestSepBy(t *testing.T) {
+	comma := Literal[string](",")
+	tests := []struct {
+		name         string
+		parser       Parser[string]
+		src          string
+		want         []string
+		wantConsumed int
+		wantErr      string
+	}{
+		{name: "empty", parser: SepBy("args", rawLiteral("a"), comma, TrailingForbid), src: "", want: []string{}},
+		{name: "single", parser: SepBy("args", rawLiteral("a"), comma, TrailingForbid), src: "a )", want: []string{"a"}, wantConsumed: 1},
+		{name: "multiple", parser: SepBy("args", rawLiteral("a"), comma, TrailingForbid), src: "a , a , a )", want: []string{"a", "a", "a"}, wantConsumed: 5},
+		{name: "trailing forbidden", parser: Between(Literal[string]("("), Literal[string](")"), SepBy("args", rawLiteral("a"), comma, TrailingForbid)), src: "( a , a , )", wantErr: "not match: trailing ',' not allowed at 5 (while parsing args)"},
+		{name: "broken element after separator", parser: Between(Literal[string]("("), Literal[string](")"), SepBy("args", rawLiteral("a"), comma, TrailingForbid)), src: "( a , + )", wantErr: "not match expected: a, actual: + at 4 (while parsing args)"},
+		{name: "broken element without closing delimiter", parser: SepBy("args", rawLiteral("a"), comma, TrailingForbid), src: "a , a , )", wantErr: "not match expected: a, actual: ) at 5 (while parsing args)"},
+		{name: "trailing forbidden at EOF", parser: SepBy("args", rawLiteral("a"), comma, TrailingForbid), src: "a ,", wantErr: "not match: trailing ',' not allowed at 2 (while parsing args)"},
+		{name: "trailing allowed", parser: SepBy("args", rawLiteral("a"), comma, TrailingAllow), src: "a , a , )", want: []string{"a", "a"}, wantConsumed: 4},
+		{name: "trailing allowed without trailing", parser: SepBy("args", rawLiteral("a"), comma, TrailingAllow), src: "a , a )", want: []string{"a", "a"}, wantConsumed: 3},
+		{name: "trailing required", parser: SepBy("args", rawLiteral("a"), comma, TrailingRequire), src: "a , a , )", want: []string{"a", "a"}, wantConsumed: 4},
+		{name: "trailing required but missing", parser: SepBy("args", rawLiteral("a"), comma, TrailingRequire), src: "a , a )", wantErr: "not match expected: ',', actual: ')' at 4 (while parsing args)"},
+		{name: "sepby1 empty", parser: SepBy1("args", rawLiteral("a"), comma, TrailingForbid), src: ")", wantErr: "repeat count expected count: 1, actual count: 0 at 1 (while parsing args)"},
+		{name: "sepby1", parser: SepBy1("args", rawLiteral("a"), comma, TrailingForbid), src: "a , a", want: []string{"a", "a"}, wantConsumed: 3},
+		{name: "sependby", parser: SepEndBy("args", rawLiteral("a"), comma), src: "a , a ,", want: []string{"a", "a"}, wantConsumed: 4},
+		{name: "endby", parser: EndBy("statements", rawLiteral("a"), Literal[string](";")), src: "a ; a ; b", want: []string{"a", "a"}, wantConsumed: 4},
+		{name: "endby missing terminator", parser: EndBy("statements", rawLiteral("a"), Literal[string](";")), src: "a ; a", wantErr: "not match expected: ';', actual: EOF at 4 (while parsing statements)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := NewParseContext[string]()
+			var src []Token[string]
+			for i, raw := range strings.Fields(tt.src) {
+				src = append(src, Token[string]{Type: "raw", Raw: raw, Val: raw, Pos: &Pos{Index: i + 1}})
+			}
+			consumed, newTokens, err := tt.parser(pc, src)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantConsumed, consumed)
+			got := []string{}
+			for _, token := range newTokens {
+				got = append(got, token.Val)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSepByErrorKinds(t *testing.T) {
+	pc := NewParseContext[string]()
+	_, err := EvaluateWithRawTokens(pc, []string{"(", "a", ",", ")"}, Between(Literal[string]("("), Literal[string](")"), SepBy("args", rawLiteral("a"), Literal[string](","), TrailingForbid)))
+	assert.True(t, errors.Is(err, ErrNotMatch))
+	var pe *ParseError
+	assert.True(t, errors.As(err, &pe))
+	assert.Equal(t, 2, pe.Pos.Index)
+
+	var nm *NotMatchError
+	assert.True(t, errors.As(err, &nm))
+	assert.Equal(t, []string{"a"}, nm.Expected)
+	assert.Equal(t, "')'", nm.Actual)
+
+	_, err = EvaluateWithRawTokens(pc, []string{"a", ","}, SepBy("args", rawLiteral("a"), Literal[string](","), TrailingForbid))
+	assert.EqualError(t, err, "not match: trailing ',' not allowed at 1 (while parsing args)")
+	assert.True(t, errors.As(err, &nm))
+	assert.Equal(t, []string{"a"}, nm.Expected)
+	assert.Equal(t, "EOF", nm.Actual)
+
+	// critical errors from elements are not swallowed
+	_, err = EvaluateWithRawTokens(pc, []string{"a", ",", "a"}, SepBy("args", Or(rawLiteral("a"), Fail[string]("broken")), Literal[string](","), TrailingForbid))
+	assert.True(t, errors.Is(err, ErrCritical))
+}
+
+func TestTrailingSeparatorString(t *testing.T) {
+	assert.Equal(t, "Forbid", TrailingForbid.String())
+	assert.Equal(t, "Allow", TrailingAllow.String())
+	assert.Equal(t, "Require", TrailingRequire.String())
+}
@@ -0,0 +1,125 @@
+package parsercombinator
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Satisfy matches a single token for which pred returns true.
+//
+// The label is used as the expected value of the error. The actual value is the token's
+// quoted Raw text (or its Type when Raw is empty), or EOF when no tokens are left.
+func Satisfy[T any](label string, pred func(token Token[T]) bool) Parser[T] {
+	return satisfy([]string{label}, pred)
+}
+
+// satisfy works like Satisfy and reports each of expected separately in NotMatchError.Expected.
+func satisfy[T any](expected []string, pred func(token Token[T]) bool) Parser[T] {
+	return func(pc *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		if len(src) == 0 {
+			return 0, nil, newErrNotMatch(expected, "EOF", nil)
+		}
+		if !pred(src[0]) {
+			return 0, nil, newErrNotMatch(expected, describeToken(src[0]), src[0].Pos)
+		}
+		return 1, src[:1], nil
+	}
+}
+
+// TokenType matches a single token whose Type is tokenType.
+func TokenType[T any](tokenType string) Parser[T] {
+	return Satisfy(tokenType, func(token Token[T]) bool {
+		return token.Type == tokenType
+	})
+}
+
+// Literal matches a single token whose Raw text is raw.
+func Literal[T any](raw string) Parser[T] {
+	return Satisfy(quoteRaw(raw), func(token Token[T]) bool {
+		return token.Raw == raw
+	})
+}
+
+// OneOfLiterals matches a single token whose Raw text is one of raws.
+func OneOfLiterals[T any](raws ...string) Parser[T] {
+	return satisfy(quoteRaws(raws), func(token Token[T]) bool {
+		return slices.Contains(raws, token.Raw)
+	})
+}
+
+// NoneOf matches a single token whose Raw text is none of raws.
+func NoneOf[T any](raws ...string) Parser[T] {
+	return Satisfy("any token except "+joinLabels(quoteRaws(raws), "or"), func(token Token[T]) bool {
+		return !slices.Contains(raws, token.Raw)
+	})
+}
+
+// AnyToken matches any single token. It fails only at the end of input.
+func AnyToken[T any]() Parser[T] {
+	return Satisfy("any token", func(token Token[T]) bool {
+		return true
+	})
+}
+
+// RawRegexp matches a single token whose Raw text matches re.
+// Anchor the pattern with ^ and $ to match the whole text.
+func RawRegexp[T any](re *regexp.Regexp) Parser[T] {
+	return Satisfy("/"+re.String()+"/", func(token Token[T]) bool {
+		return re.MatchString(token.Raw)
+	})
+}
+
+// describeToken returns the actual value of a token for error messages.
+// describeActual describes the first token of src for the actual value of errors.
+func describeActual[T any](src []Token[T]) string {
+	if len(src) == 0 {
+		return "EOF"
+	}
+	return describeToken(src[0])
+}
+
+func describeToken[T any](token Token[T]) string {
+	if token.Raw == "" {
+		return token.Type
+	}
+	return quoteRaw(token.Raw)
+}
+
+// quoteRaw quotes raw text with single quotes, escaping control characters, single quotes and backslashes.
+func quoteRaw(raw string) string {
+	var builder strings.Builder
+	builder.WriteByte('\'')
+	for _, r := range raw {
+		switch {
+		case r == '\'' || r == '\\':
+			builder.WriteByte('\\')
+			builder.WriteRune(r)
+		case unicode.IsControl(r):
+			quoted := strconv.QuoteRune(r)
+			builder.WriteString(quoted[1 : len(quoted)-1])
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	builder.WriteByte('\'')
+	return builder.String()
+}
+
+func quoteRaws(raws []string) []string {
+	result := make([]string, len(raws))
+	for i, raw := range raws {
+		result[i] = quoteRaw(raw)
+	}
+	return result
+}
+
+// joinLabels joins labels as "a, b or c".
+func joinLabels(labels []string, conjunction string) string {
+	if len(labels) <= 1 {
+		return strings.Join(labels, "")
+	}
+	return strings.Join(labels[:len(labels)-1], ", ") + " " + conjunction + " " + labels[len(labels)-1]
+}
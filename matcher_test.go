@@ -0,0 +1,64 @@
+package parsercombinator
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestTokenMatchers(t *testing.T) {
+	tokens := []Token[int]{
+		{Type: "ident", Raw: "foo", Pos: &Pos{Line: 1, Col: 1}},
+		{Type: "newline", Pos: &Pos{Line: 1, Col: 4}},
+	}
+	tests := []struct {
+		name    string
+		parser  Parser[int]
+		src     []Token[int]
+		wantErr string
+	}{
+		{name: "token type", parser: TokenType[int]("ident"), src: tokens},
+		{name: "token type mismatch", parser: TokenType[int]("number"), src: tokens, wantErr: "not match expected: number, actual: 'foo' at 1:1"},
+		{name: "token type mismatch without raw", parser: TokenType[int]("ident"), src: tokens[1:], wantErr: "not match expected: ident, actual: newline at 1:4"},
+		{name: "token type EOF", parser: TokenType[int]("ident"), src: nil, wantErr: "not match expected: ident, actual: EOF"},
+		{name: "literal", parser: Literal[int]("foo"), src: tokens},
+		{name: "literal mismatch", parser: Literal[int]("bar"), src: tokens, wantErr: "not match expected: 'bar', actual: 'foo' at 1:1"},
+		{name: "one of literals", parser: OneOfLiterals[int]("bar", "foo"), src: tokens},
+		{name: "one of literals mismatch", parser: OneOfLiterals[int]("+", "-", "*"), src: tokens, wantErr: "not match expected: '+', '-' or '*', actual: 'foo' at 1:1"},
+		{name: "none of", parser: NoneOf[int](")", "]"), src: tokens},
+		{name: "none of mismatch", parser: NoneOf[int]("foo"), src: tokens, wantErr: "not match expected: any token except 'foo', actual: 'foo' at 1:1"},
+		{name: "none of EOF", parser: NoneOf[int]("foo"), src: nil, wantErr: "not match expected: any token except 'foo', actual: EOF"},
+		{name: "any token", parser: AnyToken[int](), src: tokens[1:]},
+		{name: "any token EOF", parser: AnyToken[int](), src: nil, wantErr: "not match expected: any token, actual: EOF"},
+		{name: "raw regexp", parser: RawRegexp[int](regexp.MustCompile(`^f\w+$`)), src: tokens},
+		{name: "raw regexp mismatch", parser: RawRegexp[int](regexp.MustCompile(`^[0-9]+$`)), src: tokens, wantErr: "not match expected: /^[0-9]+$/, actual: 'foo' at 1:1"},
+		{name: "satisfy", parser: Satisfy("short identifier", func(token Token[int]) bool { return len(token.Raw) < 3 }), src: tokens, wantErr: "not match expected: short identifier, actual: 'foo' at 1:1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := NewParseContext[int]()
+			consumed, newTokens, err := tt.parser(pc, tt.src)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, consumed)
+				assert.Equal(t, tt.src[:1], newTokens)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.True(t, errors.Is(err, ErrNotMatch))
+				assert.Equal(t, 0, consumed)
+			}
+		})
+	}
+}
+
+func TestTokenMatchersQuoting(t *testing.T) {
+	pc := NewParseContext[int]()
+	_, _, err := Literal[int]("\n")(pc, []Token[int]{{Type: "text", Raw: "it's"}})
+	assert.EqualError(t, err, `not match expected: '\n', actual: 'it\'s'`)
+	_, _, err = Literal[int]("\t")(pc, []Token[int]{{Type: "string", Raw: `"s"`}})
+	assert.EqualError(t, err, `not match expected: '\t', actual: '"s"'`)
+	_, _, err = Literal[int]("\n")(pc, []Token[int]{{Type: "text", Raw: `\n`}})
+	assert.EqualError(t, err, `not match expected: '\n', actual: '\\n'`)
+}
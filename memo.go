@@ -0,0 +1,105 @@
+package parsercombinator
+
+import (
+	"maps"
+	"slices"
+)
+
+type memoRule struct {
+	name string
+}
+
+type memoEntry[T any] struct {
+	consumed int
+	tokens   []Token[T]
+	err      error
+}
+
+// MemoCounter counts the cache lookups of Memo parsers.
+type MemoCounter struct {
+	Hits    int
+	Misses  int
+	Stored  int // Results stored in the cache
+	Dropped int // Results not stored because ParseContext.MemoLimit was reached
+}
+
+// HitRate returns the ratio of lookups answered from the cache (0 when there was no lookup).
+func (c MemoCounter) HitRate() float64 {
+	if c.Hits+c.Misses == 0 {
+		return 0
+	}
+	return float64(c.Hits) / float64(c.Hits+c.Misses)
+}
+
+func (c *MemoCounter) add(hit, stored, dropped bool) {
+	if hit {
+		c.Hits++
+		return
+	}
+	c.Misses++
+	if stored {
+		c.Stored++
+	}
+	if dropped {
+		c.Dropped++
+	}
+}
+
+// MemoStats holds the Memo statistics of the last Evaluate, in total and per rule name.
+type MemoStats struct {
+	MemoCounter
+	Rules map[string]*MemoCounter
+}
+
+// RuleNames returns the names of the rules that have statistics, sorted.
+func (s MemoStats) RuleNames() []string {
+	return slices.Sorted(maps.Keys(s.Rules))
+}
+
+func (s *MemoStats) add(name string, hit, stored, dropped bool) {
+	s.MemoCounter.add(hit, stored, dropped)
+	if s.Rules == nil {
+		s.Rules = make(map[string]*MemoCounter)
+	}
+	rule, ok := s.Rules[name]
+	if !ok {
+		rule = &MemoCounter{}
+		s.Rules[name] = rule
+	}
+	rule.add(hit, stored, dropped)
+}
+
+// Memo caches the result (consumed count, tokens and error) of parser per start position
+// within one Evaluate (packrat parsing). Wrap rules that are tried repeatedly at the same
+// position, such as the shared prefixes of Or alternatives.
+//
+// The cache holds at most ParseContext.MemoLimit results and ParseContext.MemoStats reports
+// hits and misses. Side effects on the context (errors recorded by Recover, traces, changes of
+// ParseContext.State) are not replayed on a cache hit, so don't memoize rules that depend on or
+// change the user state. Results that depend on a left recursion seed are not cached.
+func Memo[T any](name string, parser Parser[T]) Parser[T] {
+	rule := &memoRule{name: name}
+	return Trace(name, func(pctx *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		key := newPositionKey(rule, src)
+		if entry, ok := pctx.memo[key]; ok {
+			pctx.MemoStats.add(name, true, false, false)
+			return entry.consumed, entry.tokens, entry.err
+		}
+		seedReads := pctx.seedReads
+		consumed, newTokens, err := parser(pctx, src)
+		if pctx.seedReads != seedReads {
+			pctx.MemoStats.add(name, false, false, false)
+			return consumed, newTokens, err
+		}
+		if pctx.MemoLimit > 0 && len(pctx.memo) >= pctx.MemoLimit {
+			pctx.MemoStats.add(name, false, false, true)
+			return consumed, newTokens, err
+		}
+		if pctx.memo == nil {
+			pctx.memo = make(map[positionKey[T]]memoEntry[T])
+		}
+		pctx.memo[key] = memoEntry[T]{consumed: consumed, tokens: newTokens, err: err}
+		pctx.MemoStats.add(name, false, true, false)
+		return consumed, newTokens, err
+	})
+}
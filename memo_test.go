@@ -0,0 +1,80 @@
+package parsercombinator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func countingParser(count *int, parser Parser[string]) Parser[string] {
+	return func(pc *ParseContext[string], src []Token[string]) (int, []Token[string], error) {
+		*count++
+		return parser(pc, src)
+	}
+}
+
+func TestMemo(t *testing.T) {
+	var count int
+	prefix := Memo("prefix", countingParser(&count, Seq(rawLiteral("x"), rawLiteral("y"))))
+	parser := Or(
+		Seq(prefix, rawLiteral("a")),
+		Seq(prefix, rawLiteral("b")),
+		Seq(prefix, rawLiteral("c")),
+	)
+
+	pc := NewParseContext[string]()
+	result, err := EvaluateWithRawTokens(pc, []string{"x", "y", "b"}, parser)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(result))
+	assert.Equal(t, 1, count)
+	assert.Equal(t, MemoCounter{Hits: 2, Misses: 1, Stored: 1}, pc.MemoStats.MemoCounter)
+	assert.Equal(t, []string{"prefix"}, pc.MemoStats.RuleNames())
+	assert.Equal(t, 2.0/3.0, pc.MemoStats.Rules["prefix"].HitRate())
+
+	// cache and statistics are reset by Evaluate
+	count = 0
+	_, err = EvaluateWithRawTokens(pc, []string{"x", "z"}, parser)
+	assert.Error(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, MemoCounter{Hits: 2, Misses: 1, Stored: 1}, pc.MemoStats.MemoCounter)
+}
+
+func TestMemoPositions(t *testing.T) {
+	var count int
+	item := Memo("item", countingParser(&count, rawLiteral("a")))
+	parser := Or(
+		Seq(ZeroOrMore("items", item), rawLiteral(";")),
+		Seq(ZeroOrMore("items", item), rawLiteral(".")),
+	)
+	pc := NewParseContext[string]()
+	_, err := EvaluateWithRawTokens(pc, strings.Fields("a a a ."), parser)
+	assert.NoError(t, err)
+	// three items plus the failing lookup at "." are evaluated once each
+	assert.Equal(t, 4, count)
+	assert.Equal(t, 4, pc.MemoStats.Hits)
+
+	count = 0
+	pc.MemoLimit = 2
+	_, err = EvaluateWithRawTokens(pc, strings.Fields("a a a ."), parser)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, count)
+	assert.Equal(t, MemoCounter{Hits: 2, Misses: 6, Stored: 2, Dropped: 4}, pc.MemoStats.MemoCounter)
+	assert.Equal(t, 0.0, MemoCounter{}.HitRate())
+}
+
+func TestMemoWithLeftRecursion(t *testing.T) {
+	defineExpr, expr := NewAlias[int]("expr")
+	number := Memo("number", leftRecNumber())
+	parser := defineExpr(Or(
+		Trans(Seq(Memo("expr-memo", expr), Literal[int]("-"), number), leftRecApply),
+		number,
+	))
+	pc := NewParseContext[int]()
+	pc.LeftRecursion = true
+	result, err := EvaluateWithRawTokens(pc, strings.Fields("10 - 3 - 2"), Seq(parser, EOS[int]()))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{5}, result)
+	assert.True(t, pc.MemoStats.Rules["number"].Hits > 0)
+	assert.Equal(t, 0, pc.MemoStats.Rules["expr-memo"].Stored)
+}
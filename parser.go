@@ -11,9 +11,27 @@ func Evaluate[T any](pctx *ParseContext[T], src []Token[T], parser Parser[T]) (r
 	pctx.Pos = 0
 	pctx.Traces = make([]*TraceInfo, 0)
 	pctx.Errors = make([]*ParseError, 0)
+	pctx.Ambiguities = nil
+	pctx.Diagnostics = nil
 	pctx.Depth = 0
+	pctx.delimiters = nil
+	pctx.cut = false
+	pctx.growing = nil
+	pctx.seedReads = 0
+	pctx.memo = nil
+	pctx.MemoStats = MemoStats{}
+	pctx.ruleStack = nil
+	pctx.farthest = farthestFailure{}
 	consumed, newTokens, err := parser(pctx, src)
 	if err != nil {
+		pctx.noteFailure(err)
+		if errors.Is(err, ErrNotMatch) {
+			// report the farthest failure with the merged expected values instead of the
+			// failure at the start position
+			if farthest := pctx.FarthestFailure(); farthest != nil {
+				err = farthest
+			}
+		}
 		var pos *Pos
 		if len(src) > 0 {
 			pos = src[0].Pos
@@ -15,7 +15,7 @@ func init() {
 	log.SetPrefix("🐙: ")
 }
 
-func Digit() Parser[int] {
+func rawDigit() Parser[int] {
 	return Trace("digit", func(pc *ParseContext[int], src []Token[int]) (int, []Token[int], error) {
 		if len(src) == 0 {
 			return 0, nil, NewErrNotMatch("digit", "EOF", nil)
@@ -36,7 +36,7 @@ func Digit() Parser[int] {
 
 var ErrWrongType = errors.New("wrong type")
 
-func String() Parser[int] {
+func rawString() Parser[int] {
 	return Trace("string", func(pc *ParseContext[int], src []Token[int]) (int, []Token[int], error) {
 		if len(src) == 0 {
 			return 0, nil, NewErrNotMatch("string", "EOF", nil)
@@ -51,7 +51,7 @@ func String() Parser[int] {
 	})
 }
 
-func Operator() Parser[int] {
+func rawOperator() Parser[int] {
 	supportedOperators := map[string]bool{
 		"+": true,
 		"-": true,
@@ -93,7 +93,7 @@ func Expression() Parser[int] {
 	return Trace("expression",
 		Trans(
 			Seq(
-				Digit(), Operator(), Digit(),
+				rawDigit(), rawOperator(), rawDigit(),
 			),
 			expressionTransform),
 	)
@@ -102,7 +102,7 @@ func Expression() Parser[int] {
 func TestSingleNode(t *testing.T) {
 	pc := NewParseContext[int]()
 	pc.TraceEnable = true
-	result, err := EvaluateWithRawTokens(pc, []string{"100"}, Digit())
+	result, err := EvaluateWithRawTokens(pc, []string{"100"}, rawDigit())
 	t.Log(pc.DumpTraceAsText())
 	assert.NoError(t, err)
 	assert.Equal(t, []int{100}, result)
@@ -111,7 +111,7 @@ func TestSingleNode(t *testing.T) {
 func TestSingleNodeError(t *testing.T) {
 	pc := NewParseContext[int]()
 	pc.TraceEnable = true
-	result, err := EvaluateWithRawTokens(pc, []string{"text"}, Digit())
+	result, err := EvaluateWithRawTokens(pc, []string{"text"}, rawDigit())
 	t.Log(pc.DumpTraceAsText())
 	assert.Zero(t, result)
 	assert.Error(t, err)
@@ -142,7 +142,7 @@ func TestOr(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			pc := NewParseContext[int]()
 			pc.TraceEnable = true
-			_, err := EvaluateWithRawTokens(pc, tt.src, Or(Digit(), String()))
+			_, err := EvaluateWithRawTokens(pc, tt.src, Or(rawDigit(), rawString()))
 			t.Log(pc.DumpTraceAsText())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Or() error = %v, wantErr %v", err, tt.wantErr)
@@ -181,7 +181,7 @@ func TestRepeat(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			pc := NewParseContext[int]()
 			pc.TraceEnable = true
-			result, err := EvaluateWithRawTokens(pc, tt.src, Repeat("digits", tt.min, tt.max, Digit()))
+			result, err := EvaluateWithRawTokens(pc, tt.src, Repeat("digits", tt.min, tt.max, rawDigit()))
 			t.Log(pc.DumpTraceAsText())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Repeat() error = %v, wantErr %v", err, tt.wantErr)
@@ -198,7 +198,7 @@ func Sum() Parser[int] {
 		return []Token[int]{{Type: "digit", Pos: src[0].Pos, Val: result}}, nil
 	}
 	return Trace("add", Trans(
-		Seq(Digit(), Digit(), EOL()),
+		Seq(rawDigit(), rawDigit(), EOL()),
 		addTransform,
 	))
 }
@@ -240,7 +240,7 @@ func TestRecover(t *testing.T) {
 			pc := NewParseContext[int]()
 			pc.TraceEnable = true
 			pattern := ZeroOrMore("sum expressions", Recover(
-				Digit(),
+				rawDigit(),
 				Sum(),
 				EOL(),
 			))
@@ -307,7 +307,7 @@ func TestErrorType(t *testing.T) {
 		},
 		{
 			name:         "Or() with not match then match",
-			parser:       Or(GenErrNotMatch(), Digit()),
+			parser:       Or(GenErrNotMatch(), rawDigit()),
 			src:          []string{"10"},
 			want:         nil,
 			wantCount:    1,
@@ -315,7 +315,7 @@ func TestErrorType(t *testing.T) {
 		},
 		{
 			name:         "Or() with repeat count then match",
-			parser:       Or(GenErrRepeatCount(), Digit()),
+			parser:       Or(GenErrRepeatCount(), rawDigit()),
 			src:          []string{"10"},
 			want:         nil,
 			wantCount:    1,
@@ -323,7 +323,7 @@ func TestErrorType(t *testing.T) {
 		},
 		{
 			name:         "Or() with critical error then match",
-			parser:       Or(GenErrCritical(), Digit()),
+			parser:       Or(GenErrCritical(), rawDigit()),
 			src:          []string{"10"},
 			want:         ErrCritical,
 			wantCount:    0,
@@ -331,7 +331,7 @@ func TestErrorType(t *testing.T) {
 		},
 		{
 			name:         "Or() with not match and not match all",
-			parser:       Or(GenErrNotMatch(), Digit()),
+			parser:       Or(GenErrNotMatch(), rawDigit()),
 			src:          []string{"test"},
 			want:         ErrNotMatch,
 			wantCount:    0,
@@ -339,7 +339,7 @@ func TestErrorType(t *testing.T) {
 		},
 		{
 			name:         "Or() with repeat count and not match all",
-			parser:       Or(GenErrRepeatCount(), Digit()),
+			parser:       Or(GenErrRepeatCount(), rawDigit()),
 			src:          []string{"test"},
 			want:         ErrNotMatch,
 			wantCount:    0,
@@ -347,7 +347,7 @@ func TestErrorType(t *testing.T) {
 		},
 		{
 			name:         "Or() with critical error and not match all",
-			parser:       Or(GenErrCritical(), Digit()),
+			parser:       Or(GenErrCritical(), rawDigit()),
 			src:          []string{"test"},
 			want:         ErrCritical,
 			wantCount:    0,
@@ -357,10 +357,10 @@ func TestErrorType(t *testing.T) {
 			name: "Recover() with not match",
 			parser: Seq(
 				Or( // first parser absorb error then second parser match
-					Recover(Digit(), Seq(Digit(), GenErrNotMatch(), Digit()), Digit()),
-					Digit(),
+					Recover(rawDigit(), Seq(rawDigit(), GenErrNotMatch(), rawDigit()), rawDigit()),
+					rawDigit(),
 				),
-				Digit(),
+				rawDigit(),
 			),
 			src:          []string{"10", "20"},
 			want:         ErrNotMatch,
@@ -371,10 +371,10 @@ func TestErrorType(t *testing.T) {
 			name: "Recover() with repeat count",
 			parser: Seq(
 				Or( // first parser absorb error then second parser match
-					Recover(Digit(), Seq(Digit(), GenErrRepeatCount(), Digit()), Digit()),
-					Digit(),
+					Recover(rawDigit(), Seq(rawDigit(), GenErrRepeatCount(), rawDigit()), rawDigit()),
+					rawDigit(),
 				),
-				Digit(),
+				rawDigit(),
 			),
 			src:          []string{"10", "20"},
 			want:         ErrRepeatCount,
@@ -385,10 +385,10 @@ func TestErrorType(t *testing.T) {
 			name: "Recover() with critical error",
 			parser: Seq(
 				Or( // first parser absorb error then second parser match
-					Recover(Digit(), Seq(Digit(), GenErrCritical(), Digit()), Digit()),
-					Digit(),
+					Recover(rawDigit(), Seq(rawDigit(), GenErrCritical(), rawDigit()), rawDigit()),
+					rawDigit(),
 				),
-				Digit(),
+				rawDigit(),
 			),
 			src:          []string{"10", "20"},
 			want:         ErrCritical,
@@ -412,9 +412,9 @@ func TestAlias(t *testing.T) {
 	expressionBody, expression := NewAlias[int]("expression")
 	parser := expressionBody(
 		Or(
-			Digit(),
+			rawDigit(),
 			Trans(
-				Seq(Operator(), expression, expression),
+				Seq(rawOperator(), expression, expression),
 				func(pctx *ParseContext[int], src []Token[int]) (converted []Token[int], err error) {
 					var result int
 					switch src[0].Raw {
@@ -516,7 +516,7 @@ func TestBefore(t *testing.T) {
 }
 
 func TestNone(t *testing.T) {
-	parser := Seq(Digit(), None[int](), Digit())
+	parser := Seq(rawDigit(), None[int](), rawDigit())
 
 	tests := []struct {
 		name      string
@@ -556,21 +556,21 @@ func TestLookahead(t *testing.T) {
 	}{
 		{
 			name:   "lookahead match - consume after check",
-			parser: Seq(Lookahead(Digit()), Digit()),
+			parser: Seq(Lookahead(rawDigit()), rawDigit()),
 			src:    []string{"100"},
 			want:   []int{100},
 		},
 		{
 			name:    "lookahead not match",
-			parser:  Seq(Lookahead(Digit()), Digit()),
+			parser:  Seq(Lookahead(rawDigit()), rawDigit()),
 			src:     []string{"test"},
 			wantErr: true,
 		},
 		{
 			name: "conditional parsing with lookahead",
 			parser: Or(
-				Seq(Lookahead(Operator()), Operator(), Digit()),
-				Digit(),
+				Seq(Lookahead(rawOperator()), rawOperator(), rawDigit()),
+				rawDigit(),
 			),
 			src:  []string{"100"},
 			want: []int{100}, // Should match the second alternative (Digit)
@@ -578,8 +578,8 @@ func TestLookahead(t *testing.T) {
 		{
 			name: "conditional parsing with lookahead - operator case",
 			parser: Or(
-				Seq(Lookahead(Operator()), Operator(), Digit()),
-				Digit(),
+				Seq(Lookahead(rawOperator()), rawOperator(), rawDigit()),
+				rawDigit(),
 			),
 			src:  []string{"+", "100"},
 			want: []int{0, 100}, // Operator produces Val=0, then digit produces 100
@@ -614,19 +614,19 @@ func TestNotFollowedBy(t *testing.T) {
 	}{
 		{
 			name:   "not followed by - success case",
-			parser: Seq(Digit(), NotFollowedBy(Operator())),
+			parser: Seq(rawDigit(), NotFollowedBy(rawOperator())),
 			src:    []string{"100", "200"},
 			want:   []int{100},
 		},
 		{
 			name:    "not followed by - fail case",
-			parser:  Seq(Digit(), NotFollowedBy(Operator())),
+			parser:  Seq(rawDigit(), NotFollowedBy(rawOperator())),
 			src:     []string{"100", "+"},
 			wantErr: true,
 		},
 		{
 			name:   "identifier not followed by digit",
-			parser: Seq(String(), NotFollowedBy(Digit())),
+			parser: Seq(rawString(), NotFollowedBy(rawDigit())),
 			src:    []string{"var", "+"},
 			want:   []int{1}, // String parser produces 1 result with Raw value, counted as 1 item
 		},
@@ -669,7 +669,7 @@ func TestPeek(t *testing.T) {
 	}{
 		{
 			name:   "peek without consuming",
-			parser: Seq(Peek(Digit()), Digit(), Digit()),
+			parser: Seq(Peek(rawDigit()), rawDigit(), rawDigit()),
 			src:    []string{"100", "200"},
 			want:   []int{100, 100, 200},
 		},
@@ -677,10 +677,10 @@ func TestPeek(t *testing.T) {
 			name: "peek for conditional logic",
 			parser: Seq(
 				Or(
-					Seq(Peek(Operator()), Operator()),
+					Seq(Peek(rawOperator()), rawOperator()),
 					None[int](),
 				),
-				Digit(),
+				rawDigit(),
 			),
 			src:  []string{"+", "100"},
 			want: []int{0, 0, 100}, // Peek produces 0, Operator produces 0, Digit produces 100
@@ -689,10 +689,10 @@ func TestPeek(t *testing.T) {
 			name: "peek for conditional logic - no operator",
 			parser: Seq(
 				Or(
-					Seq(Peek(Operator()), Operator()),
+					Seq(Peek(rawOperator()), rawOperator()),
 					None[int](),
 				),
-				Digit(),
+				rawDigit(),
 			),
 			src:  []string{"100"},
 			want: []int{100},
@@ -728,20 +728,20 @@ func TestLabel(t *testing.T) {
 	}{
 		{
 			name:   "label success case",
-			parser: Label("number", Digit()),
+			parser: Label("number", rawDigit()),
 			src:    []string{"100"},
 			want:   []int{100},
 		},
 		{
 			name:       "label error case - cleaner message",
-			parser:     Label("number", Digit()),
+			parser:     Label("number", rawDigit()),
 			src:        []string{"text"},
 			wantErr:    true,
 			wantErrMsg: "number",
 		},
 		{
 			name:       "complex parser with label",
-			parser:     Label("arithmetic expression", Seq(Digit(), Operator(), Digit())),
+			parser:     Label("arithmetic expression", Seq(rawDigit(), rawOperator(), rawDigit())),
 			src:        []string{"100", "invalid", "200"},
 			wantErr:    true,
 			wantErrMsg: "arithmetic expression",
@@ -749,8 +749,8 @@ func TestLabel(t *testing.T) {
 		{
 			name: "nested labels",
 			parser: Or(
-				Label("number", Digit()),
-				Label("text", String()),
+				Label("number", rawDigit()),
+				Label("text", rawString()),
 			),
 			src:  []string{"hello"},
 			want: []int{0}, // String parser produces Val=0 for strings
@@ -804,7 +804,7 @@ func TestExpectedAndFail(t *testing.T) {
 		{
 			name: "conditional error with Or",
 			parser: Or(
-				Digit(),
+				rawDigit(),
 				Expected[int]("valid number"),
 			),
 			src:  []string{"100"},
@@ -813,7 +813,7 @@ func TestExpectedAndFail(t *testing.T) {
 		{
 			name: "conditional error with Or - error case",
 			parser: Or(
-				Label("number", Digit()),
+				Label("number", rawDigit()),
 				Expected[int]("valid identifier"),
 			),
 			src:        []string{"invalid"},
@@ -864,8 +864,8 @@ func TestAdvancedErrorPatterns(t *testing.T) {
 		{
 			name: "fallback to specific error message",
 			parser: Or(
-				Label("number", Digit()),              // 最初に数値を試す（ラベル付き）
-				Label("text", String()),               // 次に文字列を試す（ラベル付き）
+				Label("number", rawDigit()),           // 最初に数値を試す（ラベル付き）
+				Label("text", rawString()),            // 次に文字列を試す（ラベル付き）
 				Expected[int]("number or identifier"), // どちらでもない場合は特定のエラー
 			),
 			src:  []string{"symbol"}, // この場合StringがVal=0で成功するが、Labelでラベル化される
@@ -874,8 +874,8 @@ func TestAdvancedErrorPatterns(t *testing.T) {
 		{
 			name: "true fallback error - no valid alternatives",
 			parser: Or(
-				Seq(Digit(), Operator()),          // 数値+演算子のペア
-				Seq(String(), Digit()),            // 文字列+数値のペア
+				Seq(rawDigit(), rawOperator()),    // 数値+演算子のペア
+				Seq(rawString(), rawDigit()),      // 文字列+数値のペア
 				Expected[int]("valid expression"), // どちらでもない場合
 			),
 			src:        []string{"invalid"}, // 単一の無効なトークン
@@ -885,11 +885,11 @@ func TestAdvancedErrorPatterns(t *testing.T) {
 		{
 			name: "required closing parenthesis",
 			parser: Seq(
-				Label("opening parenthesis", Operator()), // "+" を開き括弧として使用
-				Digit(),
+				Label("opening parenthesis", rawOperator()), // "+" を開き括弧として使用
+				rawDigit(),
 				Or(
-					Label("closing parenthesis", Operator()), // "-" を閉じ括弧として使用
-					Expected[int]("closing parenthesis"),     // 見つからない場合の明確なエラー
+					Label("closing parenthesis", rawOperator()), // "-" を閉じ括弧として使用
+					Expected[int]("closing parenthesis"),        // 見つからない場合の明確なエラー
 				),
 			),
 			src:        []string{"+", "100", "invalid"},
@@ -899,8 +899,8 @@ func TestAdvancedErrorPatterns(t *testing.T) {
 		{
 			name: "syntax error in expression",
 			parser: Or(
-				Seq(Digit(), Operator(), Digit()),       // 正常な式
-				Seq(Digit(), Expected[int]("operator")), // 数値の後に演算子がない
+				Seq(rawDigit(), rawOperator(), rawDigit()), // 正常な式
+				Seq(rawDigit(), Expected[int]("operator")), // 数値の後に演算子がない
 			),
 			src:        []string{"100", "invalid"},
 			wantErr:    true,
@@ -909,7 +909,7 @@ func TestAdvancedErrorPatterns(t *testing.T) {
 		{
 			name: "conditional feature availability",
 			parser: Or(
-				Digit(), // 実装済み機能
+				rawDigit(), // 実装済み機能
 				Fail[int]("advanced expressions not implemented in this version"), // 未実装機能
 			),
 			src:        []string{"function_call"},
@@ -977,7 +977,7 @@ func TestZeroOrMore(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			pc := NewParseContext[int]()
 			pc.TraceEnable = true
-			result, err := EvaluateWithRawTokens(pc, tt.src, ZeroOrMore("digits", Digit()))
+			result, err := EvaluateWithRawTokens(pc, tt.src, ZeroOrMore("digits", rawDigit()))
 			t.Log(pc.DumpTraceAsText())
 
 			if (err != nil) != tt.wantErr {
@@ -1028,7 +1028,7 @@ func TestOneOrMore(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			pc := NewParseContext[int]()
 			pc.TraceEnable = true
-			result, err := EvaluateWithRawTokens(pc, tt.src, OneOrMore("digits", Digit()))
+			result, err := EvaluateWithRawTokens(pc, tt.src, OneOrMore("digits", rawDigit()))
 			t.Log(pc.DumpTraceAsText())
 
 			if (err != nil) != tt.wantErr {
@@ -1043,8 +1043,8 @@ func TestOneOrMore(t *testing.T) {
 	}
 }
 
-// Space parser for whitespace
-func Space() Parser[int] {
+// rawSpace parser for whitespace
+func rawSpace() Parser[int] {
 	return Trace("space", func(pc *ParseContext[int], src []Token[int]) (int, []Token[int], error) {
 		if src[0].Type == "raw" && src[0].Raw == " " {
 			return 1, []Token[int]{{Type: "space", Pos: src[0].Pos, Raw: " "}}, nil
@@ -1062,7 +1062,7 @@ func TestSeqWithZeroOrMoreEdgeCase(t *testing.T) {
 	// This should work: digit followed by zero or more spaces
 	// Input: just "5" (no spaces after)
 	// Expected: should succeed because ZeroOrMore should match zero spaces
-	parser := Seq(Digit(), ZeroOrMore("spaces", Space()))
+	parser := Seq(rawDigit(), ZeroOrMore("spaces", rawSpace()))
 
 	result, err := EvaluateWithRawTokens(pc, []string{"5"}, parser)
 	t.Log(pc.DumpTraceAsText())
@@ -1084,7 +1084,7 @@ func TestSeqWithZeroOrMoreWithSpaces(t *testing.T) {
 	pc := NewParseContext[int]()
 	pc.TraceEnable = false
 
-	parser := Seq(Digit(), ZeroOrMore("spaces", Space()))
+	parser := Seq(rawDigit(), ZeroOrMore("spaces", rawSpace()))
 
 	// This should work fine: digit followed by spaces
 	result, err := EvaluateWithRawTokens(pc, []string{"5", " ", " "}, parser)
@@ -1128,17 +1128,17 @@ func TestStackOverflowProtection(t *testing.T) {
 					Or(
 						// The recursive case comes first to trigger left recursion immediately
 						Trans(
-							Seq(expression, Operator(), Digit()),
+							Seq(expression, rawOperator(), rawDigit()),
 							func(pctx *ParseContext[int], src []Token[int]) (converted []Token[int], err error) {
 								return []Token[int]{{Type: "digit", Pos: src[0].Pos, Val: 0}}, nil
 							},
 						),
-						Digit(), // Base case
+						rawDigit(), // Base case
 					),
 				)
 			} else {
 				// Use a simple, non-recursive parser
-				parser = Digit()
+				parser = rawDigit()
 			}
 
 			_, err := EvaluateWithRawTokens(pc, tt.src, parser)
@@ -1178,8 +1178,8 @@ func TestStackOverflowWithSimpleRecursion(t *testing.T) {
 // TestOrLongestMatch tests the longest match behavior of Or parser
 func TestOrLongestMatch(t *testing.T) {
 	// Create parsers for testing longest match
-	shortMatch := Seq(String(), String())          // matches 2 tokens
-	longMatch := Seq(String(), String(), String()) // matches 3 tokens
+	shortMatch := Seq(rawString(), rawString())             // matches 2 tokens
+	longMatch := Seq(rawString(), rawString(), rawString()) // matches 3 tokens
 
 	tests := []struct {
 		name     string
@@ -1224,8 +1224,8 @@ func TestOrZeroTokenConsumption(t *testing.T) {
 	pc.TraceEnable = true
 
 	// Parsers that consume 0 tokens
-	optional1 := Optional(String())
-	optional2 := Optional(Digit())
+	optional1 := Optional(rawString())
+	optional2 := Optional(rawDigit())
 
 	parser := Or(optional1, optional2)
 
@@ -1245,8 +1245,8 @@ func TestOrZeroTokenConsumption(t *testing.T) {
 // TestOrModes tests different Or parser modes
 func TestOrModes(t *testing.T) {
 	// Create parsers for testing mode differences
-	shortMatch := Seq(String(), String())          // matches 2 tokens, returns faster
-	longMatch := Seq(String(), String(), String()) // matches 3 tokens, but slower
+	shortMatch := Seq(rawString(), rawString())             // matches 2 tokens, returns faster
+	longMatch := Seq(rawString(), rawString(), rawString()) // matches 3 tokens, but slower
 
 	testInput := []string{"a", "b", "c"}
 
@@ -1302,9 +1302,9 @@ func TestOrModePerformance(t *testing.T) {
 	pc2.OrMode = OrModeFast
 
 	// Create a more complex scenario where performance difference matters
-	failingParser1 := Seq(String(), String(), String(), String()) // fails
-	failingParser2 := Seq(String(), String(), String(), Digit())  // fails
-	succeedingParser := String()                                  // succeeds immediately
+	failingParser1 := Seq(rawString(), rawString(), rawString(), rawString()) // fails
+	failingParser2 := Seq(rawString(), rawString(), rawString(), rawDigit())  // fails
+	succeedingParser := rawString()                                           // succeeds immediately
 
 	parser := Or(failingParser1, failingParser2, succeedingParser)
 	testInput := []string{"hello"}
@@ -1386,8 +1386,8 @@ func TestOrModeWithAmbiguousGrammar(t *testing.T) {
 
 // TestOrHelperFunctions tests the convenience helper functions
 func TestOrHelperFunctions(t *testing.T) {
-	shortMatch := Seq(String(), String())          // matches 2 tokens
-	longMatch := Seq(String(), String(), String()) // matches 3 tokens
+	shortMatch := Seq(rawString(), rawString())             // matches 2 tokens
+	longMatch := Seq(rawString(), rawString(), rawString()) // matches 3 tokens
 	testInput := []string{"a", "b", "c"}
 
 	tests := []struct {
@@ -1500,8 +1500,8 @@ func TestOrModeTryFastWarning(t *testing.T) {
 	pc.TraceEnable = false
 
 	// Create parsers where order matters for demonstration
-	shortParser := Seq(String(), String())          // consumes 2 tokens
-	longParser := Seq(String(), String(), String()) // consumes 3 tokens
+	shortParser := Seq(rawString(), rawString())             // consumes 2 tokens
+	longParser := Seq(rawString(), rawString(), rawString()) // consumes 3 tokens
 
 	// Put short parser first (suboptimal for Fast mode)
 	parser := Or(shortParser, longParser)
@@ -1526,7 +1526,7 @@ func TestTransformationSafetyCheck(t *testing.T) {
 		pc.CheckTransformSafety = true
 
 		// Parser that matches a digit
-		digitParser := Digit()
+		digitParser := rawDigit()
 
 		// Safe transformer that changes type but not structure
 		safeTransformer := func(pc *ParseContext[int], tokens []Token[int]) ([]Token[int], error) {
@@ -1658,7 +1658,7 @@ func TestTransformationSafetyCheck(t *testing.T) {
 		pc.OrMode = OrModeSafe
 		pc.CheckTransformSafety = true
 
-		digitParser := Digit()
+		digitParser := rawDigit()
 
 		// Transformer that returns empty tokens
 		emptyTransformer := func(pc *ParseContext[int], tokens []Token[int]) ([]Token[int], error) {
@@ -1799,7 +1799,7 @@ func TestTransformationSafetyWarning(t *testing.T) {
 		pc.OrMode = OrModeSafe
 		pc.CheckTransformSafety = true
 
-		digitParser := Digit()
+		digitParser := rawDigit()
 
 		// Transformer that significantly changes the structure
 		changingTransformer := func(pc *ParseContext[int], tokens []Token[int]) ([]Token[int], error) {
@@ -1835,7 +1835,7 @@ func TestLazyParser(t *testing.T) {
 
 		// Create a simple lazy parser
 		lazyDigit := Lazy(func() Parser[int] {
-			return Digit()
+			return rawDigit()
 		})
 
 		consumed, result, err := lazyDigit(pc, []Token[int]{
@@ -1857,7 +1857,7 @@ func TestLazyParser(t *testing.T) {
 
 		// Primary parser handles numbers and parenthesized expressions
 		primary := Or(
-			Digit(),
+			rawDigit(),
 			Trans(
 				Seq(
 					Trace("lparen", func(pc *ParseContext[int], src []Token[int]) (int, []Token[int], error) {
@@ -1886,7 +1886,7 @@ func TestLazyParser(t *testing.T) {
 			Trans(
 				Seq(
 					primary,
-					Operator(),
+					rawOperator(),
 					Lazy(func() Parser[int] { return expression }),
 				),
 				func(pc *ParseContext[int], tokens []Token[int]) ([]Token[int], error) {
@@ -1932,10 +1932,10 @@ func TestLazyParser(t *testing.T) {
 
 		// Use right recursion instead of left recursion
 		recursiveParser = Or(
-			Digit(),
+			rawDigit(),
 			Lazy(func() Parser[int] {
 				return Trans(
-					Seq(Digit(), Operator(), recursiveParser),
+					Seq(rawDigit(), rawOperator(), recursiveParser),
 					func(pc *ParseContext[int], tokens []Token[int]) ([]Token[int], error) {
 						left := tokens[0].Val
 						op := tokens[1].Raw
@@ -0,0 +1,118 @@
+package parsercombinator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Clause is a named element of Permutation. Name is used in error messages.
+type Clause[T any] struct {
+	Name   string
+	Parser Parser[T]
+}
+
+// NewClause creates a Permutation clause.
+func NewClause[T any](name string, parser Parser[T]) Clause[T] {
+	return Clause[T]{Name: name, Parser: parser}
+}
+
+// Permutation matches the clauses in any order, each at most once, and returns their tokens in
+// declaration order (required clauses first, then optional ones) regardless of the input order:
+//
+//	query := pc.Permutation("query-options",
+//		[]pc.Clause[T]{pc.NewClause("ORDER BY", orderBy)},
+//		pc.NewClause("LIMIT", limit),
+//		pc.NewClause("OFFSET", offset),
+//	)
+//
+// A clause that appears twice is reported as "ORDER BY specified twice" at the second occurrence,
+// and a missing required clause as "missing required LIMIT" where the clauses end.
+// When several clauses match at the same position, the longest match wins.
+func Permutation[T any](label string, required []Clause[T], optional ...Clause[T]) Parser[T] {
+	clauses := make([]Clause[T], 0, len(required)+len(optional))
+	clauses = append(clauses, required...)
+	clauses = append(clauses, optional...)
+	return Trace(label, func(pctx *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		results := make([][]Token[T], len(clauses))
+		firstPos := make([]*Pos, len(clauses))
+		matched := make([]bool, len(clauses))
+		offset := 0
+		for offset < len(src) {
+			best := -1
+			bestConsumed := 0
+			var bestTokens []Token[T]
+			saved := pctx.snapshot()
+			bestSaved := saved
+			for i, clause := range clauses {
+				pctx.restore(saved)
+				consumed, newTokens, err := clause.Parser(pctx, src[offset:])
+				if err != nil {
+					if errors.Is(err, ErrNotMatch) || errors.Is(err, ErrRepeatCount) {
+						pctx.noteFailure(err)
+						continue
+					}
+					return 0, nil, err
+				}
+				// a clause that consumes nothing can't be told apart from a missing one
+				if consumed > bestConsumed {
+					best, bestConsumed, bestTokens, bestSaved = i, consumed, newTokens, pctx.snapshot()
+				}
+			}
+			pctx.restore(bestSaved)
+			if best < 0 {
+				break
+			}
+			if matched[best] {
+				var expected []string
+				for i, clause := range clauses {
+					if !matched[i] {
+						expected = append(expected, clause.Name)
+					}
+				}
+				return 0, nil, newErrNotMatchMessage(fmt.Sprintf("%s specified twice (first at %s)", clauses[best].Name, firstPos[best]),
+					expected, describeToken(src[offset]), src[offset].Pos)
+			}
+			matched[best] = true
+			firstPos[best] = src[offset].Pos
+			results[best] = bestTokens
+			offset += bestConsumed
+		}
+
+		var missing []string
+		for i := range required {
+			if !matched[i] {
+				missing = append(missing, required[i].Name)
+			}
+		}
+		if len(missing) > 0 {
+			var pos *Pos
+			if offset < len(src) {
+				pos = src[offset].Pos
+			} else if offset > 0 {
+				pos = clausesEnd(src[offset-1])
+			}
+			return 0, nil, newErrNotMatchMessage(fmt.Sprintf("missing required %s", joinLabels(missing, "and")),
+				missing, describeActual(src[offset:]), pos)
+		}
+
+		converted := make([]Token[T], 0, offset)
+		for _, tokens := range results {
+			converted = append(converted, tokens...)
+		}
+		return offset, converted, nil
+	})
+}
+
+// clausesEnd returns where the clauses end: a zero-length position right after the last
+// consumed token. Index-only positions (EvaluateWithRawTokens) count tokens, so it is the next index.
+func clausesEnd[T any](last Token[T]) *Pos {
+	if last.Pos == nil {
+		return nil
+	}
+	if last.Pos.Line == 0 && last.Pos.Col == 0 {
+		return &Pos{Index: last.Pos.Index + 1, File: last.Pos.File}
+	}
+	c := cursor{line: last.Pos.Line, col: last.Pos.Col, index: last.Pos.Index, file: last.Pos.File}
+	c.advance(last.Raw)
+	return c.pos(0)
+}
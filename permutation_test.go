@@ -0,0 +1,75 @@
+package parsercombinator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestPermutation(t *testing.T) {
+	clause := func(keyword ...string) Parser[string] {
+		parsers := make([]Parser[string], 0, len(keyword)+1)
+		for _, k := range keyword {
+			parsers = append(parsers, Drop(rawLiteral(k)))
+		}
+		parsers = append(parsers, TokenType[string]("raw"))
+		return Seq(parsers...)
+	}
+	options := Permutation("options",
+		[]Clause[string]{NewClause("ORDER BY", clause("ORDER", "BY"))},
+		NewClause("LIMIT", clause("LIMIT")),
+		NewClause("OFFSET", clause("OFFSET")),
+	)
+	parser := Seq(options, EOS[string]())
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr string
+
+		wantExpected []string
+		wantActual   string
+	}{
+		{name: "canonical order", input: "ORDER BY id LIMIT 10 OFFSET 20", want: []string{"id", "10", "20"}},
+		{name: "any order", input: "OFFSET 20 ORDER BY id LIMIT 10", want: []string{"id", "10", "20"}},
+		{name: "optional omitted", input: "LIMIT 10 ORDER BY id", want: []string{"id", "10"}},
+		{name: "duplicate", input: "ORDER BY id LIMIT 10 ORDER BY name", wantErr: "not match: ORDER BY specified twice (first at 0) at 5 (while parsing options)", wantExpected: []string{"OFFSET"}, wantActual: "'ORDER'"},
+		{name: "missing", input: "LIMIT 10 OFFSET 20", wantErr: "not match: missing required ORDER BY at 4 (while parsing options)", wantExpected: []string{"ORDER BY"}, wantActual: "EOF"},
+		{name: "empty", input: "", wantErr: "not match: missing required ORDER BY (while parsing options)", wantExpected: []string{"ORDER BY"}, wantActual: "EOF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := NewParseContext[string]()
+			result, err := EvaluateWithRawTokens(pc, strings.Fields(tt.input), parser)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				var nm *NotMatchError
+				assert.True(t, errors.As(err, &nm))
+				assert.Equal(t, tt.wantExpected, nm.Expected)
+				assert.Equal(t, tt.wantActual, nm.Actual)
+				return
+			}
+			assert.NoError(t, err)
+			raws := make([]string, len(pc.Results))
+			for i, r := range pc.Results {
+				raws[i] = r.Raw
+			}
+			assert.Equal(t, tt.want, raws)
+			assert.Equal(t, len(tt.want), len(result))
+		})
+	}
+}
+
+func TestPermutationMissingPositions(t *testing.T) {
+	pc := NewParseContext[string]()
+	parser := Permutation("attributes", []Clause[string]{
+		NewClause("width", rawLiteral("width")),
+		NewClause("height", rawLiteral("height")),
+		NewClause("depth", rawLiteral("depth")),
+	})
+	_, err := Evaluate(pc, []Token[string]{{Type: "raw", Raw: "height", Pos: &Pos{Line: 1, Col: 1, Length: 6}}}, parser)
+	assert.EqualError(t, err, "not match: missing required width and depth at 1:7 (while parsing attributes)")
+}
@@ -0,0 +1,36 @@
+package parsercombinator
+
+import (
+	"slices"
+)
+
+// RuleStack returns the names of the rules being parsed, outermost first.
+//
+// Rules are the parsers named by Trace, SeqWithLabel, Label, NewAlias and the labels of
+// Repeat, SepBy and the other labeled combinators. Built-in combinators like Or and Optional
+// are not rules.
+func (pc *ParseContext[T]) RuleStack() []string {
+	return slices.Clone(pc.ruleStack)
+}
+
+func (pc *ParseContext[T]) popRule() {
+	pc.ruleStack = pc.ruleStack[:len(pc.ruleStack)-1]
+}
+
+// stampRuleStack returns a copy of err with the current rule stack unless err already has one,
+// so the innermost rule that saw the error wins. err itself is not modified, because the same
+// error may be shared between rules or cached by Memo.
+func (pc *ParseContext[T]) stampRuleStack(err error) error {
+	pe, ok := err.(*ParseError)
+	if !ok || pe.RuleStack != nil || len(pc.ruleStack) == 0 {
+		return err
+	}
+	stamped := *pe
+	stamped.RuleStack = slices.Clone(pc.ruleStack)
+	if nm, ok := pe.Parent.(*NotMatchError); ok && nm.RuleStack == nil {
+		copied := *nm
+		copied.RuleStack = stamped.RuleStack
+		stamped.Parent = &copied
+	}
+	return &stamped
+}
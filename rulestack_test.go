@@ -0,0 +1,67 @@
+package parsercombinator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestRuleStack(t *testing.T) {
+	var stacks [][]string
+	ident := func(pctx *ParseContext[string], src []Token[string]) (int, []Token[string], error) {
+		stacks = append(stacks, pctx.RuleStack())
+		return NoneOf[string]("(", ")", ",", ":")(pctx, src)
+	}
+	param := SeqWithLabel("param", ident, rawLiteral(":"), Label("type", ident))
+	defineFunction, _ := NewAlias[string]("function")
+	parser := defineFunction(Seq(
+		rawLiteral("func"), ident,
+		Between(Literal[string]("("), Literal[string](")"), SepBy("params", param, Literal[string](","), TrailingForbid)),
+	))
+
+	pc := NewParseContext[string]()
+	_, err := EvaluateWithRawTokens(pc, strings.Fields("func f ( a : int , b : )"), Seq(parser, EOS[string]()))
+	assert.Error(t, err)
+	assert.Equal(t, "not match expected: type, actual: ')' at 9 (while parsing function > params > param > type)", err.Error())
+
+	var pe *ParseError
+	assert.True(t, errors.As(err, &pe))
+	assert.Equal(t, []string{"function", "params", "param", "type"}, pe.RuleStack)
+	var nm *NotMatchError
+	assert.True(t, errors.As(err, &nm))
+	assert.Equal(t, pe.RuleStack, nm.RuleStack)
+
+	// built-in combinators like Seq, Or and Between are not rules
+	assert.Equal(t, []string{"function"}, stacks[0])
+	assert.Equal(t, []string{"function", "params", "param"}, stacks[1])
+	assert.Equal(t, []string{"function", "params", "param", "type"}, stacks[2])
+	assert.Equal(t, 0, len(pc.RuleStack()))
+}
+
+func TestRuleStackInnermostRuleWins(t *testing.T) {
+	pc := NewParseContext[string]()
+	inner := Trace("inner", rawLiteral("x"))
+	_, err := EvaluateWithRawTokens(pc, []string{"y"}, Trace("outer", Seq(inner)))
+	assert.EqualError(t, err, "not match expected: x, actual: y at 0 (while parsing outer > inner)")
+
+	// errors outside of any rule have no stack
+	_, err = EvaluateWithRawTokens(pc, []string{"y"}, Or(rawLiteral("x"), rawLiteral("z")))
+	assert.EqualError(t, err, "not match expected: x or z, actual: y at 0")
+}
+
+func TestRuleStackSharedError(t *testing.T) {
+	// a shared error value is not modified by the rules it passes through
+	shared := &ParseError{Parent: &NotMatchError{Expected: []string{"x"}, Actual: "y"}}
+	fail := func(pctx *ParseContext[string], src []Token[string]) (int, []Token[string], error) {
+		return 0, nil, shared
+	}
+	pc := NewParseContext[string]()
+	_, err := EvaluateWithRawTokens(pc, []string{"y"}, Trace("first", Parser[string](fail)))
+	assert.Contains(t, err.Error(), "(while parsing first)")
+	_, err = EvaluateWithRawTokens(pc, []string{"y"}, Trace("second", Parser[string](fail)))
+	assert.Contains(t, err.Error(), "(while parsing second)")
+	assert.Equal(t, 0, len(shared.RuleStack))
+	assert.Equal(t, 0, len(shared.Parent.(*NotMatchError).RuleStack))
+}
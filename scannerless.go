@@ -0,0 +1,99 @@
+package parsercombinator
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RuneTokens converts src into one token per rune for scannerless parsing.
+//
+// Each token has Type "rune", the rune in Raw and a Pos with 1-based Line/Col
+// (Col counts runes) and the byte offset/length in Index/Length.
+func RuneTokens[T any](src string) []Token[T] {
+	tokens := make([]Token[T], 0, utf8.RuneCountInString(src))
+	c := cursor{line: 1, col: 1}
+	for _, r := range src {
+		raw := string(r)
+		tokens = append(tokens, Token[T]{Type: "rune", Pos: c.pos(len(raw)), Raw: raw})
+		c.advance(raw)
+	}
+	return tokens
+}
+
+// EvaluateString parses src directly without a separate lexer. See RuneTokens for the token layout.
+func EvaluateString[T any](pc *ParseContext[T], src string, parser Parser[T]) (result []T, err error) {
+	return Evaluate(pc, RuneTokens[T](src), parser)
+}
+
+// runeMatcher creates a parser that consumes one rune token satisfying pred.
+func runeMatcher[T any](label string, pred func(r rune) bool) Parser[T] {
+	return Satisfy(label, func(token Token[T]) bool {
+		r, size := utf8.DecodeRuneInString(token.Raw)
+		return size != 0 && size == len(token.Raw) && pred(r)
+	})
+}
+
+// Char matches the single rune c.
+func Char[T any](c rune) Parser[T] {
+	return runeMatcher[T](strconv.QuoteRune(c), func(r rune) bool {
+		return r == c
+	})
+}
+
+// Range matches a single rune between lo and hi (inclusive).
+func Range[T any](lo, hi rune) Parser[T] {
+	return runeMatcher[T](strconv.QuoteRune(lo)+"-"+strconv.QuoteRune(hi), func(r rune) bool {
+		return lo <= r && r <= hi
+	})
+}
+
+// Digit matches a single decimal digit rune.
+func Digit[T any]() Parser[T] {
+	return runeMatcher[T]("digit", unicode.IsDigit)
+}
+
+// Letter matches a single letter rune.
+func Letter[T any]() Parser[T] {
+	return runeMatcher[T]("letter", unicode.IsLetter)
+}
+
+// Space matches a single white space rune (including newlines).
+func Space[T any]() Parser[T] {
+	return runeMatcher[T]("space", unicode.IsSpace)
+}
+
+// String matches the literal text lit and returns it as one token of Type "string".
+//
+// The returned token's Pos starts at the first rune and its Length covers the whole literal.
+func String[T any](lit string) Parser[T] {
+	label := quoteRaw(lit)
+	return func(pc *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		var builder strings.Builder
+		i := 0
+		for builder.Len() < len(lit) {
+			if i >= len(src) {
+				return 0, nil, NewErrNotMatch(label, "EOF", getFirstPos(src))
+			}
+			builder.WriteString(src[i].Raw)
+			if !strings.HasPrefix(lit, builder.String()) {
+				return 0, nil, NewErrNotMatch(label, quoteRaw(builder.String()), getFirstPos(src))
+			}
+			i++
+		}
+		return i, []Token[T]{{Type: "string", Pos: spanPos(src[:i]), Raw: lit}}, nil
+	}
+}
+
+// spanPos returns a position starting at the first token and covering all tokens up to the last one.
+func spanPos[T any](tokens []Token[T]) *Pos {
+	if len(tokens) == 0 || tokens[0].Pos == nil {
+		return nil
+	}
+	pos := tokens[0].Pos.Copy()
+	if last := tokens[len(tokens)-1].Pos; last != nil && last != tokens[0].Pos && last.File == pos.File {
+		pos.Length = last.Index + last.Length - pos.Index
+	}
+	return pos
+}
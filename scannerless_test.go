@@ -0,0 +1,95 @@
+package parsercombinator
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestRuneTokens(t *testing.T) {
+	tokens := RuneTokens[int]("aé\nb")
+	assert.Equal(t, 4, len(tokens))
+	assert.Equal(t, "é", tokens[1].Raw)
+	assert.Equal(t, Pos{Line: 1, Col: 2, Index: 1, Length: 2}, *tokens[1].Pos)
+	assert.Equal(t, Pos{Line: 1, Col: 3, Index: 3, Length: 1}, *tokens[2].Pos)
+	assert.Equal(t, Pos{Line: 2, Col: 1, Index: 4, Length: 1}, *tokens[3].Pos)
+}
+
+func scannerlessNumber() Parser[int] {
+	return Trans(OneOrMore("digits", Digit[int]()), func(pc *ParseContext[int], src []Token[int]) ([]Token[int], error) {
+		var raw string
+		for _, t := range src {
+			raw += t.Raw
+		}
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, err
+		}
+		return []Token[int]{{Type: "number", Pos: spanPos(src), Raw: raw, Val: v}}, nil
+	})
+}
+
+func TestEvaluateString(t *testing.T) {
+	spaces := Drop(ZeroOrMore("spaces", Space[int]()))
+	list := Seq(
+		Drop(Char[int]('[')),
+		spaces,
+		scannerlessNumber(),
+		ZeroOrMore("rest", Seq(spaces, Drop(Char[int](',')), spaces, scannerlessNumber())),
+		spaces,
+		Drop(Char[int](']')),
+		EOS[int](),
+	)
+
+	pc := NewParseContext[int]()
+	result, err := EvaluateString(pc, "[12, 3,\n 456 ]", list)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{12, 3, 456}, result)
+	assert.Equal(t, Pos{Line: 2, Col: 2, Index: 9, Length: 3}, *pc.Results[2].Pos)
+
+	pc = NewParseContext[int]()
+	_, err = EvaluateString(pc, "[12,\n x]", list)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrRepeatCount))
+	assert.Contains(t, err.Error(), "at 2:2")
+}
+
+func TestCharClasses(t *testing.T) {
+	tests := []struct {
+		name   string
+		parser Parser[int]
+		src    string
+		want   bool
+	}{
+		{name: "char match", parser: Char[int]('x'), src: "x", want: true},
+		{name: "char not match", parser: Char[int]('x'), src: "y", want: false},
+		{name: "range match", parser: Range[int]('a', 'f'), src: "c", want: true},
+		{name: "range not match", parser: Range[int]('a', 'f'), src: "g", want: false},
+		{name: "digit", parser: Digit[int](), src: "7", want: true},
+		{name: "letter", parser: Letter[int](), src: "ß", want: true},
+		{name: "letter not match", parser: Letter[int](), src: "1", want: false},
+		{name: "space", parser: Space[int](), src: "\t", want: true},
+		{name: "string match", parser: Seq(String[int]("let"), EOS[int]()), src: "let", want: true},
+		{name: "string partial", parser: String[int]("let"), src: "le", want: false},
+		{name: "string mismatch", parser: String[int]("let"), src: "lot", want: false},
+		{name: "empty input", parser: Digit[int](), src: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := NewParseContext[int]()
+			_, err := EvaluateString(pc, tt.src, tt.parser)
+			assert.Equal(t, tt.want, err == nil, "error: %v", err)
+		})
+	}
+}
+
+func TestStringToken(t *testing.T) {
+	pc := NewParseContext[int]()
+	_, err := EvaluateString(pc, "  while", Seq(Drop(ZeroOrMore("spaces", Space[int]())), Or(String[int]("while"), String[int]("when"))))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pc.Results))
+	assert.Equal(t, "while", pc.Results[0].Raw)
+	assert.Equal(t, Pos{Line: 1, Col: 3, Index: 2, Length: 5}, *pc.Results[0].Pos)
+}
@@ -0,0 +1,43 @@
+package parsercombinator
+
+// Source is one input file registered in a SourceSet.
+type Source struct {
+	ID   int
+	Path string
+	Text string
+}
+
+// SourceSet registers the files that make up one token stream.
+//
+// Tokens created from a registered Source (see Lexer.TokenizeSource) carry it in Pos.File,
+// so positions and errors print as path:line:col even when the tokens of several files
+// are concatenated into one stream.
+type SourceSet struct {
+	files []*Source
+}
+
+func NewSourceSet() *SourceSet {
+	return &SourceSet{}
+}
+
+// Add registers a file and returns its Source.
+func (s *SourceSet) Add(path, text string) *Source {
+	source := &Source{ID: len(s.files), Path: path, Text: text}
+	s.files = append(s.files, source)
+	return source
+}
+
+// Get returns the registered file with the path, or nil.
+func (s *SourceSet) Get(path string) *Source {
+	for _, f := range s.files {
+		if f.Path == path {
+			return f
+		}
+	}
+	return nil
+}
+
+// Files returns all registered files in registration order.
+func (s *SourceSet) Files() []*Source {
+	return s.files
+}
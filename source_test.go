@@ -0,0 +1,66 @@
+package parsercombinator
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestPosStringWithFile(t *testing.T) {
+	file := &Source{Path: "conf/main.conf"}
+	assert.Equal(t, "conf/main.conf:3:7", (&Pos{Line: 3, Col: 7, File: file}).String())
+	assert.Equal(t, "conf/main.conf:12", (&Pos{Index: 12, File: file}).String())
+	assert.Equal(t, "3:7", (&Pos{Line: 3, Col: 7}).String())
+	assert.Equal(t, file, (&Pos{Line: 1, Col: 1, File: file}).Copy().File)
+}
+
+func TestSourceSet(t *testing.T) {
+	sources := NewSourceSet()
+	main := sources.Add("main.conf", "a = 1;\ninclude b;\n")
+	sub := sources.Add("b.conf", "b = 2;\n  c = ;\n")
+	assert.Equal(t, 1, sub.ID)
+	assert.Equal(t, sub, sources.Get("b.conf"))
+	assert.Zero(t, sources.Get("c.conf"))
+	assert.Equal(t, []*Source{main, sub}, sources.Files())
+
+	lexer, err := NewLexer[string](
+		SkipRule(`\s+`),
+		RegexpRule("ident", `[a-z]+`),
+		RegexpRule("number", `[0-9]+`),
+		RegexpRule("punct", `[=;]`),
+	)
+	assert.NoError(t, err)
+	var tokens []Token[string]
+	for _, f := range sources.Files() {
+		fileTokens, err := lexer.TokenizeSource(f)
+		assert.NoError(t, err)
+		tokens = append(tokens, fileTokens...)
+	}
+	assert.Equal(t, "main.conf:2:1", tokens[4].Pos.String())
+	assert.Equal(t, "b.conf:1:1", tokens[7].Pos.String())
+
+	semicolon := Literal[string](";")
+	pc := NewParseContext[string]()
+	parts := Split(pc, semicolon, tokens)
+	assert.Equal(t, 5, len(parts))
+	assert.Equal(t, "b.conf:1:1", parts[2].Skipped[0].Pos.String())
+	assert.Equal(t, "b.conf:2:3", parts[3].Skipped[0].Pos.String())
+
+	assignment := Seq(TokenType[string]("ident"), TokenType[string]("punct"), TokenType[string]("number"))
+	_, match, _, remained, found := Find(pc, rawLiteral("c"), tokens)
+	assert.True(t, found)
+	_, err = Evaluate(pc, slices.Concat(match, remained), assignment)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "at b.conf:2:7")
+}
+
+func TestTokenizeSourceLossless(t *testing.T) {
+	source := NewSourceSet().Add("a.txt", "x  y\n")
+	lexer, err := NewLexer[int](SkipRule(`\s+`), RegexpRule("ident", `[a-z]+`))
+	assert.NoError(t, err)
+	tokens, err := lexer.TokenizeSourceLossless(source)
+	assert.NoError(t, err)
+	assert.Equal(t, source.Text, SourceText(tokens...))
+	assert.Equal(t, "a.txt:1:2", tokens[0].Trailing[0].Pos.String())
+}
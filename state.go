@@ -0,0 +1,48 @@
+package parsercombinator
+
+import "slices"
+
+// GetState returns ParseContext.State as S, or the zero value of S if no state of that type is set.
+//
+// The state is rolled back by taking a snapshot of the State value: Or restores it before each
+// alternative and keeps the state of the chosen one, Optional, Repeat and the list combinators
+// restore it when an attempt fails, and Lookahead, NotFollowedBy and Peek always restore it.
+// So the state must be treated as immutable: replace it with SetState instead of modifying it
+// in place (copy maps and slices before changing them, or use a persistent data structure).
+// Errors recorded by Recover are rolled back the same way.
+func GetState[S, T any](pctx *ParseContext[T]) S {
+	state, _ := pctx.State.(S)
+	return state
+}
+
+// SetState replaces ParseContext.State. Set the initial state before Evaluate; Evaluate keeps it as is.
+func SetState[S, T any](pctx *ParseContext[T], state S) {
+	pctx.State = state
+}
+
+// UpdateState replaces the state with the result of update, which receives the current state.
+func UpdateState[S, T any](pctx *ParseContext[T], update func(state S) S) {
+	pctx.State = update(GetState[S](pctx))
+}
+
+// contextSnapshot records the parts of ParseContext that are rolled back when a branch fails:
+// the user state, the errors recorded by Recover, the reported ambiguities and the cut flag of
+// the enclosing sequence.
+type contextSnapshot[T any] struct {
+	state       any
+	errors      []*ParseError
+	ambiguities []*Ambiguity[T]
+	cut         bool
+}
+
+func (pc *ParseContext[T]) snapshot() contextSnapshot[T] {
+	// clip so that values appended by a later branch don't overwrite this snapshot
+	return contextSnapshot[T]{state: pc.State, errors: slices.Clip(pc.Errors), ambiguities: slices.Clip(pc.Ambiguities), cut: pc.cut}
+}
+
+func (pc *ParseContext[T]) restore(s contextSnapshot[T]) {
+	pc.State = s.state
+	pc.Errors = s.errors
+	pc.Ambiguities = s.ambiguities
+	pc.cut = s.cut
+}
@@ -0,0 +1,145 @@
+package parsercombinator
+
+import (
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+// typedefs is the state of a C-like declaration parser: the set of declared type names.
+type typedefs map[string]bool
+
+func declare(name string) Parser[string] {
+	return func(pctx *ParseContext[string], src []Token[string]) (int, []Token[string], error) {
+		UpdateState(pctx, func(names typedefs) typedefs {
+			names = maps.Clone(names)
+			if names == nil {
+				names = typedefs{}
+			}
+			names[name] = true
+			return names
+		})
+		return 0, nil, nil
+	}
+}
+
+func typeName(pctx *ParseContext[string], src []Token[string]) (int, []Token[string], error) {
+	if len(src) == 0 || !GetState[typedefs](pctx)[src[0].Raw] {
+		return 0, nil, NewErrNotMatch("type name", "identifier", getFirstPos(src))
+	}
+	return 1, src[:1], nil
+}
+
+func TestStateRollback(t *testing.T) {
+	ident := NoneOf[string](";")
+	typedef := Bind(Seq(Drop(rawLiteral("typedef")), ident, ident), func(result []Token[string]) Parser[string] {
+		return Seq(rawLiteral(";"), declare(result[1].Raw))
+	})
+
+	tests := []struct {
+		name  string
+		run   func(pc *ParseContext[string]) error
+		names []string
+	}{
+		{
+			name: "or keeps the state of the chosen branch only",
+			run: func(pc *ParseContext[string]) error {
+				failing := Seq(declare("a"), declare("b"), rawLiteral("x"))
+				_, err := EvaluateWithRawTokens(pc, []string{"y"}, Or(failing, Seq(declare("c"), rawLiteral("y"))))
+				return err
+			},
+			names: []string{"c"},
+		},
+		{
+			name: "optional",
+			run: func(pc *ParseContext[string]) error {
+				_, err := EvaluateWithRawTokens(pc, []string{"y"}, Seq(Optional(Seq(declare("a"), rawLiteral("x"))), rawLiteral("y")))
+				return err
+			},
+			names: []string{},
+		},
+		{
+			name: "lookahead",
+			run: func(pc *ParseContext[string]) error {
+				_, err := EvaluateWithRawTokens(pc, []string{"y"}, Seq(Lookahead(Seq(declare("a"), rawLiteral("y"))), rawLiteral("y")))
+				return err
+			},
+			names: []string{},
+		},
+		{
+			name: "repeat",
+			run: func(pc *ParseContext[string]) error {
+				_, err := EvaluateWithRawTokens(pc, strings.Fields("typedef int i32 ; typedef long i64"), ZeroOrMore("typedefs", typedef))
+				return err
+			},
+			names: []string{"i32"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := NewParseContext[string]()
+			SetState(pc, typedefs{})
+			assert.NoError(t, tt.run(pc))
+			names := make([]string, 0)
+			for name := range GetState[typedefs](pc) {
+				names = append(names, name)
+			}
+			assert.Equal(t, tt.names, names)
+		})
+	}
+}
+
+func TestStateDependentParsing(t *testing.T) {
+	ident := NoneOf[string](";")
+	typedef := Bind(Seq(Drop(rawLiteral("typedef")), ident, ident), func(result []Token[string]) Parser[string] {
+		return Seq(Drop(rawLiteral(";")), declare(result[1].Raw))
+	})
+	// "a * b ;" is a declaration if a is a type name, a multiplication otherwise
+	declaration := Trans(Seq(typeName, Drop(rawLiteral("*")), ident, Drop(rawLiteral(";"))), func(pctx *ParseContext[string], src []Token[string]) ([]Token[string], error) {
+		return []Token[string]{{Type: "declaration", Pos: src[0].Pos, Raw: src[1].Raw}}, nil
+	})
+	expression := Trans(Seq(ident, rawLiteral("*"), ident, Drop(rawLiteral(";"))), func(pctx *ParseContext[string], src []Token[string]) ([]Token[string], error) {
+		return []Token[string]{{Type: "expression", Pos: src[0].Pos, Raw: src[0].Raw}}, nil
+	})
+	program := ZeroOrMore("program", FastOr(typedef, declaration, expression))
+
+	pc := NewParseContext[string]()
+	_, err := EvaluateWithRawTokens(pc, strings.Fields("a * b ; typedef int a ; a * b ;"), program)
+	assert.NoError(t, err)
+	types := make([]string, len(pc.Results))
+	for i, r := range pc.Results {
+		types[i] = r.Type
+	}
+	assert.Equal(t, []string{"expression", "declaration"}, types)
+	assert.Equal(t, typedefs{"a": true}, GetState[typedefs](pc))
+	assert.Equal(t, 0, GetState[int](pc))
+}
+
+func TestErrorsRollback(t *testing.T) {
+	// statement: "x = <digit> ;" with recovery until ";" when the value is broken
+	recovering := Recover(rawLiteral("x"), Seq(rawLiteral("x"), rawLiteral("="), rawLiteral("1"), rawLiteral(";")), rawLiteral(";"))
+	command := Seq(rawLiteral("x"), rawLiteral("="), rawLiteral("2"), rawLiteral(";"))
+
+	for _, mode := range []OrMode{OrModeSafe, OrModeFast, OrModeTryFast} {
+		t.Run(mode.String(), func(t *testing.T) {
+			pc := NewParseContext[string]()
+			pc.OrMode = mode
+
+			// the recovered branch is shorter than the command, so its error is discarded
+			_, err := EvaluateWithRawTokens(pc, strings.Fields("x = 2 ;"), Or(Seq(recovering, rawLiteral("end")), command))
+			assert.NoError(t, err)
+			assert.Equal(t, 0, len(pc.Errors))
+
+			// the errors of the winning branch are kept
+			_, err = EvaluateWithRawTokens(pc, strings.Fields("x = 3 ;"), Or(recovering, Seq(rawLiteral("x"), rawLiteral("y"))))
+			assert.EqualError(t, err, "not match expected: 1, actual: 3 at 2")
+			assert.Equal(t, 1, len(pc.Errors))
+		})
+	}
+
+	pc := NewParseContext[string]()
+	_, err := EvaluateWithRawTokens(pc, strings.Fields("x = 3 ;"), Seq(Optional(Seq(recovering, rawLiteral("end"))), ZeroOrMore("rest", AnyToken[string]())))
+	assert.NoError(t, err)
+}
@@ -3,9 +3,7 @@ package parsercombinator
 import (
 	"errors"
 	"fmt"
-	"os"
 	"reflect"
-	"runtime"
 	"strings"
 )
 
@@ -14,10 +12,14 @@ type Alias[T any] struct {
 	name string
 }
 
+// NewAlias creates a named rule that can be referenced before it is defined (for recursive grammars).
+//
+// When ParseContext.LeftRecursion is enabled, the rule may be left-recursive, directly
+// (expr := expr "+" term | term) or indirectly through other aliases.
 func NewAlias[T any](name string) (instance func(Parser[T]) Parser[T], alias Parser[T]) {
-	i := &Alias[T]{}
+	i := &Alias[T]{name: name}
 	alias = func(pctx *ParseContext[T], tokens []Token[T]) (int, []Token[T], error) {
-		return Trace(name+"-alias", i.body)(pctx, tokens)
+		return traceRule(name+"-alias", name, i.parse)(pctx, tokens)
 	}
 	instance = i.define
 	return
@@ -27,11 +29,32 @@ func (a *Alias[T]) define(alias Parser[T]) Parser[T] {
 	a.body = alias
 
 	return func(pctx *ParseContext[T], tokens []Token[T]) (int, []Token[T], error) {
-		return Trace(a.name+"-instance", a.body)(pctx, tokens)
+		return traceRule(a.name+"-instance", a.name, a.parse)(pctx, tokens)
 	}
 }
 
+func (a *Alias[T]) parse(pctx *ParseContext[T], tokens []Token[T]) (int, []Token[T], error) {
+	if pctx.LeftRecursion {
+		return growLeftRecursion(pctx, a, a.body, tokens)
+	}
+	return a.body(pctx, tokens)
+}
+
+// Trace records the parser in ParseContext.Traces when tracing is enabled and limits the
+// recursion depth. The name is also pushed on the rule stack reported by errors
+// (see ParseError.RuleStack).
 func Trace[T any](name string, p Parser[T]) Parser[T] {
+	return traceRule(name, name, p)
+}
+
+// trace works like Trace for the built-in combinators ("or", "optional" ...). Their names
+// aren't grammar rules, so they aren't pushed on the rule stack.
+func trace[T any](name string, p Parser[T]) Parser[T] {
+	return traceRule(name, "", p)
+}
+
+// traceRule traces the parser as name and pushes rule on the rule stack unless it is empty.
+func traceRule[T any](name, rule string, p Parser[T]) Parser[T] {
 	return func(pctx *ParseContext[T], tokens []Token[T]) (int, []Token[T], error) {
 		var pos *Pos
 		if len(tokens) > 0 {
@@ -43,6 +66,13 @@ func Trace[T any](name string, p Parser[T]) Parser[T] {
 			return 0, nil, err
 		}
 		defer pctx.DecrementDepth()
+		if pctx.ReportAmbiguity && pctx.Depth == pctx.alternativeDepth+1 && pctx.alternativeLabel == "" {
+			pctx.alternativeLabel = name
+		}
+		if rule != "" {
+			pctx.ruleStack = append(pctx.ruleStack, rule)
+			defer pctx.popRule()
+		}
 
 		if pctx.TraceEnable {
 			pctx.Traces = append(pctx.Traces, &TraceInfo{
@@ -54,6 +84,9 @@ func Trace[T any](name string, p Parser[T]) Parser[T] {
 		}
 		traceIndex := len(pctx.Traces)
 		consumed, newTokens, err := p(pctx, tokens)
+		if err != nil {
+			err = pctx.stampRuleStack(err)
+		}
 		if pctx.TraceEnable {
 			tt := Match
 			if err != nil {
@@ -105,12 +138,22 @@ type Transformer[T any] func(pctx *ParseContext[T], src []Token[T]) (converted [
 //}
 
 func Seq[T any](parsers ...Parser[T]) Parser[T] {
-	return SeqWithLabel("seq", parsers...)
+	return trace("seq", sequence(parsers))
 }
 
+// SeqWithLabel works like Seq and names the sequence as a rule (see Trace).
 func SeqWithLabel[T any](label string, parsers ...Parser[T]) Parser[T] {
+	return Trace(label, sequence(parsers))
+}
+
+func sequence[T any](parsers []Parser[T]) Parser[T] {
 	//var origin = Log(3, "🐙")
-	return Trace(label, func(pctx *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+	return func(pctx *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		// Cut applies to the innermost sequence only
+		outerCut := pctx.cut
+		pctx.cut = false
+		defer func() { pctx.cut = outerCut }()
+
 		converted := make([]Token[T], 0, len(parsers))
 		offset := 0
 		for _, p := range parsers {
@@ -125,28 +168,49 @@ func SeqWithLabel[T any](label string, parsers ...Parser[T]) Parser[T] {
 
 			consumed, newTokens, err := p(pctx, currentSrc)
 			if err != nil {
+				if pctx.cut && (errors.Is(err, ErrNotMatch) || errors.Is(err, ErrRepeatCount)) {
+					err = NewErrCommitted(err)
+				}
 				return 0, src, err
 			}
 			converted = append(converted, newTokens...)
 			offset += consumed
 		}
 		return offset, converted, nil
-	})
+	}
 }
 
 func Or[T any](parsers ...Parser[T]) Parser[T] {
-	return Trace("or", func(pctx *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
-		var allError []error
+	return trace("or", func(pctx *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		return parseOr(pctx, src, pctx.OrMode, parsers)
+	})
+}
 
-		switch pctx.OrMode {
-		case OrModeFast:
-			return orFast(pctx, src, parsers, allError)
-		case OrModeTryFast:
-			return orTryFast(pctx, src, parsers, allError)
-		default: // OrModeSafe
-			return orSafe(pctx, src, parsers, allError)
+// parseOr runs the alternatives in mode. When an alternative fails with an Expected fallback,
+// its message replaces the failures of the other alternatives.
+func parseOr[T any](pctx *ParseContext[T], src []Token[T], mode OrMode, parsers []Parser[T]) (int, []Token[T], error) {
+	var allError []error
+	before := pctx.farthest.clone()
+
+	var consumed int
+	var newTokens []Token[T]
+	var err error
+	switch mode {
+	case OrModeFast:
+		consumed, newTokens, err = orFast(pctx, src, parsers, allError)
+	case OrModeTryFast:
+		consumed, newTokens, err = orTryFast(pctx, src, parsers, allError)
+	default: // OrModeSafe
+		consumed, newTokens, err = orSafe(pctx, src, parsers, allError)
+	}
+	if err != nil && errors.Is(err, ErrNotMatch) {
+		if fallback := expectedFallback(err); fallback != nil {
+			pctx.farthest = before
+			pctx.noteFailure(fallback)
+			return 0, nil, fallback
 		}
-	})
+	}
+	return consumed, newTokens, err
 }
 
 // orSafe implements longest match logic (default, safe behavior)
@@ -155,34 +219,49 @@ func orSafe[T any](pctx *ParseContext[T], src []Token[T], parsers []Parser[T], a
 		consumed  int
 		newTokens []Token[T]
 		hasResult bool
+		saved     contextSnapshot[T]
 	}
 
-	for _, p := range parsers {
-		consumed, newTokens, err := p(pctx, src)
+	var matches []alternativeMatch[T]
+	saved := pctx.snapshot()
+	for i, p := range parsers {
+		// every alternative starts from the same user state and errors
+		pctx.restore(saved)
+		consumed, newTokens, label, err := parseAlternative(pctx, src, p)
 
 		if err == nil { // match
+			if pctx.ReportAmbiguity {
+				matches = append(matches, alternativeMatch[T]{index: i, label: label, consumed: consumed, tokens: newTokens})
+			}
 			// Always choose the parser that consumes the most tokens (longest match)
 			if !bestResult.hasResult || consumed > bestResult.consumed {
 				bestResult.consumed = consumed
 				bestResult.newTokens = newTokens
 				bestResult.hasResult = true
+				bestResult.saved = pctx.snapshot()
 			}
 			continue
 		}
 
 		// not match - try other options for non-critical errors
 		if errors.Is(err, ErrNotMatch) || errors.Is(err, ErrRepeatCount) {
+			pctx.noteFailure(err)
 			allError = append(allError, err)
 			continue
 		}
-		// critical error (including stack overflow)
+		// critical error (including committed and stack overflow errors)
 		return consumed, nil, err
 	}
 
 	if bestResult.hasResult {
+		pctx.restore(bestResult.saved)
+		if pctx.ReportAmbiguity {
+			reportAmbiguity(pctx, src, matches, bestResult.consumed)
+		}
 		return bestResult.consumed, bestResult.newTokens, nil
 	}
 
+	pctx.restore(saved)
 	return 0, nil, &ParseError{
 		Parent: errors.Join(allError...),
 		Pos:    getFirstPos(src),
@@ -191,7 +270,9 @@ func orSafe[T any](pctx *ParseContext[T], src []Token[T], parsers []Parser[T], a
 
 // orFast implements first match logic (performance optimized)
 func orFast[T any](pctx *ParseContext[T], src []Token[T], parsers []Parser[T], allError []error) (int, []Token[T], error) {
+	saved := pctx.snapshot()
 	for _, p := range parsers {
+		pctx.restore(saved)
 		consumed, newTokens, err := p(pctx, src)
 
 		if err == nil { // match - return immediately (first match)
@@ -200,13 +281,15 @@ func orFast[T any](pctx *ParseContext[T], src []Token[T], parsers []Parser[T], a
 
 		// not match - try other options for non-critical errors
 		if errors.Is(err, ErrNotMatch) || errors.Is(err, ErrRepeatCount) {
+			pctx.noteFailure(err)
 			allError = append(allError, err)
 			continue
 		}
-		// critical error (including stack overflow)
+		// critical error (including committed and stack overflow errors)
 		return consumed, nil, err
 	}
 
+	pctx.restore(saved)
 	return 0, nil, &ParseError{
 		Parent: errors.Join(allError...),
 		Pos:    getFirstPos(src),
@@ -220,6 +303,7 @@ func orTryFast[T any](pctx *ParseContext[T], src []Token[T], parsers []Parser[T]
 		newTokens []Token[T]
 		hasResult bool
 		index     int
+		saved     contextSnapshot[T]
 	}
 	var bestMatch struct {
 		consumed  int
@@ -228,7 +312,9 @@ func orTryFast[T any](pctx *ParseContext[T], src []Token[T], parsers []Parser[T]
 		index     int
 	}
 
+	saved := pctx.snapshot()
 	for i, p := range parsers {
+		pctx.restore(saved)
 		consumed, newTokens, err := p(pctx, src)
 
 		if err == nil { // match
@@ -238,6 +324,7 @@ func orTryFast[T any](pctx *ParseContext[T], src []Token[T], parsers []Parser[T]
 				firstMatch.newTokens = newTokens
 				firstMatch.hasResult = true
 				firstMatch.index = i
+				firstMatch.saved = pctx.snapshot()
 			}
 
 			// Record best match (longest)
@@ -252,46 +339,32 @@ func orTryFast[T any](pctx *ParseContext[T], src []Token[T], parsers []Parser[T]
 
 		// not match - try other options for non-critical errors
 		if errors.Is(err, ErrNotMatch) || errors.Is(err, ErrRepeatCount) {
+			pctx.noteFailure(err)
 			allError = append(allError, err)
 			continue
 		}
-		// critical error (including stack overflow)
+		// critical error (including committed and stack overflow errors)
 		return consumed, nil, err
 	}
 
 	if firstMatch.hasResult {
 		// Check if longest match would choose differently
 		if bestMatch.hasResult && (firstMatch.index != bestMatch.index || firstMatch.consumed != bestMatch.consumed) {
-			pos := getFirstPos(src)
-
-			// Get caller information using runtime to find where Or was called
-			var location = "unknown location"
-			for i := 1; i < 15; i++ { // Check up to 15 levels
-				_, file, line, ok := runtime.Caller(i)
-				if ok {
-					// Skip our internal files (tools.go, parser.go) to find user code
-					if !strings.Contains(file, "tools.go") &&
-						!strings.Contains(file, "parser.go") &&
-						!strings.Contains(file, "/go/src/") &&
-						!strings.Contains(file, "/usr/") {
-						// Extract just the filename from the full path
-						parts := strings.Split(file, "/")
-						filename := parts[len(parts)-1]
-						location = fmt.Sprintf("%s:%d", filename, line)
-						break
-					}
-				}
-			}
-
-			fmt.Fprintf(os.Stderr, "⚠️  Or parser optimization suggestion at %s (parser position %s):\n", location, pos)
-			fmt.Fprintf(os.Stderr, "   Fast mode chose option %d (consumed %d tokens), but longest match would choose option %d (consumed %d tokens).\n",
-				firstMatch.index+1, firstMatch.consumed, bestMatch.index+1, bestMatch.consumed)
-			fmt.Fprintf(os.Stderr, "   For Fast mode compatibility, consider moving option %d before option %d in your Or(...) call.\n",
-				bestMatch.index+1, firstMatch.index+1)
+			pctx.Report(Diagnostic{
+				Severity: SeverityWarning,
+				Code:     DiagOrFastOrder,
+				Message: fmt.Sprintf("Fast mode chose option %d (consumed %d tokens), but longest match would choose option %d (consumed %d tokens)",
+					firstMatch.index+1, firstMatch.consumed, bestMatch.index+1, bestMatch.consumed),
+				Pos:        getFirstPos(src),
+				Location:   callerLocation(),
+				Suggestion: fmt.Sprintf("move option %d before option %d in the Or(...) call for Fast mode compatibility", bestMatch.index+1, firstMatch.index+1),
+			})
 		}
+		pctx.restore(firstMatch.saved)
 		return firstMatch.consumed, firstMatch.newTokens, nil
 	}
 
+	pctx.restore(saved)
 	return 0, nil, &ParseError{
 		Parent: errors.Join(allError...),
 		Pos:    getFirstPos(src),
@@ -319,10 +392,16 @@ func Trans[T any](parser Parser[T], tf Transformer[T]) Parser[T] {
 
 		// Check transformation safety in Safe mode if enabled
 		if pc.OrMode == OrModeSafe && pc.CheckTransformSafety {
-			err := checkTransformSafety(pc, parser, newTokens, result)
-			if err != nil {
-				// Log warning but don't fail the parse
-				fmt.Fprintf(os.Stderr, "Warning: Transformation safety check failed: %v\n", err)
+			if err := checkTransformSafety(pc, parser, newTokens, result); err != nil {
+				// Report a warning but don't fail the parse
+				pc.Report(Diagnostic{
+					Severity:   SeverityWarning,
+					Code:       DiagTransformLoop,
+					Message:    err.Error(),
+					Pos:        getFirstPos(src),
+					Location:   callerLocation(),
+					Suggestion: "make the transformed tokens different from what the parser produces (e.g. change their Type)",
+				})
 			}
 		}
 
@@ -339,8 +418,11 @@ func Repeat[T any](label string, min uint, max int, parser Parser[T]) Parser[T]
 			if offset >= len(tokens) {
 				break
 			}
+			saved := pctx.snapshot()
 			consumed, newTokens, err := parser(pctx, tokens[offset:])
 			if errors.Is(err, ErrNotMatch) {
+				pctx.noteFailure(err)
+				pctx.restore(saved)
 				break
 			} else if err != nil {
 				return 0, []Token[T]{}, err
@@ -368,12 +450,15 @@ func ZeroOrMore[T any](label string, parser Parser[T]) Parser[T] {
 }
 
 func Optional[T any](parser Parser[T]) Parser[T] {
-	return Trace("optional", func(pctx *ParseContext[T], tokens []Token[T]) (int, []Token[T], error) {
+	return trace("optional", func(pctx *ParseContext[T], tokens []Token[T]) (int, []Token[T], error) {
+		saved := pctx.snapshot()
 		consumed, newTokens, err := parser(pctx, tokens)
 		if err == nil {
 			return consumed, newTokens, nil
 		}
 		if errors.Is(err, ErrNotMatch) || errors.Is(err, ErrRepeatCount) {
+			pctx.noteFailure(err)
+			pctx.restore(saved)
 			return 0, []Token[T]{}, nil
 		}
 		return 0, []Token[T]{}, err
@@ -381,7 +466,7 @@ func Optional[T any](parser Parser[T]) Parser[T] {
 }
 
 func Before[T any](callback func(token Token[T]) bool) Parser[T] {
-	return Trace("before", func(pctx *ParseContext[T], tokens []Token[T]) (int, []Token[T], error) {
+	return trace("before", func(pctx *ParseContext[T], tokens []Token[T]) (int, []Token[T], error) {
 		for i, t := range tokens {
 			if callback(t) {
 				return i, tokens[:i], nil
@@ -402,15 +487,17 @@ func None[T any](label ...string) Parser[T] {
 }
 
 func Recover[T any](search, body, skipUntil Parser[T]) Parser[T] {
-	return Trace("recover", func(pc *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
-		_, _, err := Trace("precondition-check", search)(pc, src)
+	return trace("recover", func(pc *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		_, _, err := trace("precondition-check", search)(pc, src)
 		if err != nil {
 			return 0, nil, err
 		}
-		consumed, newTokens, err := Trace("process", body)(pc, src)
+		saved := pc.snapshot()
+		consumed, newTokens, err := trace("process", body)(pc, src)
 		if err != nil {
+			pc.restore(saved)
 			pc.AppendError(err, src[0].Pos)
-			return Trace("healing", func(pc *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+			return trace("healing", func(pc *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
 				for i := range src {
 					consumed, _, err = skipUntil(pc, src[i:])
 					if err == nil {
@@ -427,8 +514,12 @@ func Recover[T any](search, body, skipUntil Parser[T]) Parser[T] {
 // Lookahead checks if the parser matches without consuming tokens
 // Returns empty tokens if match, error if not match
 func Lookahead[T any](parser Parser[T]) Parser[T] {
-	return Trace("lookahead", func(pc *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+	return trace("lookahead", func(pc *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		// failures inside a lookahead don't tell what the input should continue with
+		saved, farthest := pc.snapshot(), pc.farthest.clone()
 		_, _, err := parser(pc, src)
+		pc.restore(saved)
+		pc.farthest = farthest
 		if err != nil {
 			return 0, nil, err
 		}
@@ -439,8 +530,11 @@ func Lookahead[T any](parser Parser[T]) Parser[T] {
 // NotFollowedBy succeeds if the parser does NOT match (negative lookahead)
 // Returns empty tokens if parser fails, error if parser succeeds
 func NotFollowedBy[T any](parser Parser[T]) Parser[T] {
-	return Trace("not-followed-by", func(pc *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+	return trace("not-followed-by", func(pc *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		saved, farthest := pc.snapshot(), pc.farthest.clone()
 		_, _, err := parser(pc, src)
+		pc.restore(saved)
+		pc.farthest = farthest
 		if err == nil {
 			var pos *Pos
 			if len(src) > 0 {
@@ -455,8 +549,11 @@ func NotFollowedBy[T any](parser Parser[T]) Parser[T] {
 // Peek returns the result of the parser without consuming tokens
 // Useful for inspection or conditional parsing
 func Peek[T any](parser Parser[T]) Parser[T] {
-	return Trace("peek", func(pc *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+	return trace("peek", func(pc *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		saved, farthest := pc.snapshot(), pc.farthest.clone()
 		_, newTokens, err := parser(pc, src)
+		pc.restore(saved)
+		pc.farthest = farthest
 		if err != nil {
 			return 0, nil, err
 		}
@@ -472,29 +569,45 @@ func FollowedBy[T any](parser Parser[T]) Parser[T] {
 // Label provides a user-friendly label for error messages
 // When the parser fails, it replaces technical error details with the provided label
 // Unlike Trace, this is purely for error message improvement, not debugging
+//
+// The expected values of the failures inside the parser are replaced as well, unless the parser
+// got beyond the first token; then the farther failure is more precise and is still reported.
 func Label[T any](label string, parser Parser[T]) Parser[T] {
 	return func(pc *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		pc.ruleStack = append(pc.ruleStack, label)
+		defer pc.popRule()
+		before := pc.farthest.clone()
+		pc.farthest = farthestFailure{}
 		consumed, newTokens, err := parser(pc, src)
+		inner := pc.farthest
+		pc.farthest = before
 		if err != nil {
-			var pos *Pos
-			if len(src) > 0 {
-				pos = src[0].Pos
+			inner.note(err)
+			if inner.found && comparePos(inner.pos, getFirstPos(src)) > 0 {
+				pc.farthest.merge(inner)
 			}
-			return consumed, nil, NewErrNotMatch(label, "not matched", pos)
+			return consumed, nil, pc.stampRuleStack(NewErrNotMatch(label, describeActual(src), getFirstPos(src)))
 		}
+		pc.farthest.merge(inner)
 		return consumed, newTokens, nil
 	}
 }
 
 // Expected creates a parser that fails with a specific expected message
 // Useful for creating custom error messages or placeholders
+//
+// As the last alternative of Or, it is a fallback: its message replaces the expected values of
+// the other alternatives.
 func Expected[T any](message string) Parser[T] {
 	return func(pc *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
 		var pos *Pos
 		if len(src) > 0 {
 			pos = src[0].Pos
 		}
-		return 0, nil, NewErrNotMatch(message, "found something else", pos)
+		return 0, nil, &ParseError{
+			Parent: &NotMatchError{Expected: []string{message}, Actual: describeActual(src), Pos: pos, fallback: true},
+			Pos:    pos,
+		}
 	}
 }
 
@@ -512,17 +625,8 @@ func Fail[T any](message string) Parser[T] {
 
 // OrWithMode creates an Or parser with specific mode for this instance
 func OrWithMode[T any](mode OrMode, parsers ...Parser[T]) Parser[T] {
-	return Trace("or", func(pctx *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
-		var allError []error
-
-		switch mode {
-		case OrModeFast:
-			return orFast(pctx, src, parsers, allError)
-		case OrModeTryFast:
-			return orTryFast(pctx, src, parsers, allError)
-		default: // OrModeSafe
-			return orSafe(pctx, src, parsers, allError)
-		}
+	return trace("or", func(pctx *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+		return parseOr(pctx, src, mode, parsers)
 	})
 }
 
@@ -544,14 +648,29 @@ func TryFastOr[T any](parsers ...Parser[T]) Parser[T] {
 // Lazy creates a lazy parser that evaluates the parser function when called
 // This prevents infinite loops during parser construction by deferring parser resolution
 // until parsing time, allowing for true recursive definitions
+//
+// When ParseContext.LeftRecursion is enabled, the rule may be left-recursive if it refers
+// to itself through the same Lazy parser:
+//
+//	var expr Parser[T]
+//	expr = Lazy(func() Parser[T] { return Or(Seq(expr, plus, term), term) })
 func Lazy[T any](parserFactory func() Parser[T]) Parser[T] {
-	return Trace("lazy", func(pc *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
+	rule := &lazyRule[T]{factory: parserFactory}
+	return trace("lazy", func(pc *ParseContext[T], src []Token[T]) (int, []Token[T], error) {
 		// Get the actual parser when parsing is performed
 		parser := parserFactory()
+		if pc.LeftRecursion {
+			return growLeftRecursion(pc, rule, parser, src)
+		}
 		return parser(pc, src)
 	})
 }
 
+// lazyRule identifies a Lazy parser for left recursion handling
+type lazyRule[T any] struct {
+	factory func() Parser[T]
+}
+
 // checkTransformSafety verifies that a transformation is safe by checking if
 // applying the same parser to the transformed tokens would produce the same result.
 // This helps detect infinite loops in transformations.
@@ -583,13 +702,7 @@ func checkTransformSafety[T any](pc *ParseContext[T], parser Parser[T], original
 
 	// Check if reparsing produces the same result as the transformed tokens
 	if reflect.DeepEqual(transformedTokens, reparsedTokens) {
-		// Get caller information for better error reporting
-		_, file, line, ok := runtime.Caller(4) // Adjusted to find the actual transformation call
-		if ok {
-			return fmt.Errorf("potential infinite loop in transformation at %s:%d - parser produces same result when applied to transformed tokens", file, line)
-		} else {
-			return fmt.Errorf("potential infinite loop in transformation - parser produces same result when applied to transformed tokens")
-		}
+		return fmt.Errorf("potential infinite loop in transformation - parser produces same result when applied to transformed tokens")
 	}
 
 	return nil
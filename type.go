@@ -40,16 +40,21 @@ type Pos struct {
 	Col    int
 	Index  int
 	Length int
+	File   *Source // Source file the position belongs to (nil for anonymous input)
 }
 
 func (p *Pos) String() string {
 	if p == nil {
 		return "1:1"
 	}
+	var result []byte
+	if p.File != nil {
+		result = append(result, p.File.Path...)
+		result = append(result, ':')
+	}
 	if p.Line == 0 && p.Col == 0 {
-		return strconv.Itoa(p.Index)
+		return string(strconv.AppendInt(result, int64(p.Index), 10))
 	}
-	var result []byte
 	result = strconv.AppendInt(result, int64(p.Line), 10)
 	result = append(result, ':')
 	result = strconv.AppendInt(result, int64(p.Col), 10)
@@ -57,14 +62,19 @@ func (p *Pos) String() string {
 }
 
 func (p Pos) Copy() *Pos {
-	return &Pos{Line: p.Line, Col: p.Col, Index: p.Index, Length: p.Length}
+	return &Pos{Line: p.Line, Col: p.Col, Index: p.Index, Length: p.Length, File: p.File}
 }
 
 type Token[T any] struct {
-	Type string
-	Pos  *Pos
-	Raw  string
-	Val  T
+	Type     string
+	Pos      *Pos
+	Raw      string
+	Val      T
+	Leading  []Trivia   // Whitespace and comments before the token (see Lexer.TokenizeLossless)
+	Trailing []Trivia   // Whitespace and comments after the token on the same line
+	Children []Token[T] // Child tokens of a concrete syntax tree node (see CST)
+
+	triviaHolder bool // Holds the trivia of a source without tokens; EOS consumes it
 }
 
 func (t Token[T]) GoString() string {
@@ -97,6 +107,25 @@ type ParseContext[T any] struct {
 	MaxDepth             int    // Maximum allowed recursion depth (0 means no limit)
 	OrMode               OrMode // Or parser behavior mode (default: OrModeSafe)
 	CheckTransformSafety bool   // Enable transformation safety checks (default: false)
+	LeftRecursion        bool   // Support left-recursive NewAlias/Lazy rules by seed growing (default: false)
+	MemoLimit            int    // Maximum number of results cached by Memo parsers (0 means no limit)
+	MemoStats            MemoStats
+	State                any  // User state, rolled back when a branch fails (see GetState)
+	ReportAmbiguity      bool // Record Or alternatives that tie on the longest match in Ambiguities (default: false)
+	Ambiguities          []*Ambiguity[T]
+	Diagnostics          []Diagnostic   // Warnings reported during the last Evaluate
+	DiagnosticSink       DiagnosticSink // Receives each diagnostic as it is reported (nil: only collected)
+
+	delimiters []openDelimiter[T]                   // Delimiters opened by enclosing Between parsers
+	cut        bool                                 // Whether the innermost sequence has passed a Cut
+	growing    map[positionKey[T]]*leftRecursion[T] // Left-recursive rules being grown at a position
+	seedReads  int                                  // Number of times a left recursion seed was used
+	memo       map[positionKey[T]]memoEntry[T]      // Results cached by Memo parsers
+
+	ruleStack        []string        // Names of the rules being parsed (see RuleStack)
+	farthest         farthestFailure // Not match errors at the farthest position (see FarthestFailure)
+	alternativeDepth int             // Depth of the Or whose alternative is being labeled
+	alternativeLabel string          // Name of the first Trace entered by the current alternative
 }
 
 func (pc *ParseContext[T]) AppendError(err error, pos *Pos) error {